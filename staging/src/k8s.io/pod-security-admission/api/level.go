@@ -0,0 +1,61 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import "fmt"
+
+// Level defines the Pod Security Admission policy level a pod is evaluated
+// or enforced against.
+type Level string
+
+const (
+	// LevelPrivileged is an unrestricted policy level, providing the widest
+	// possible level of permissions.
+	LevelPrivileged Level = "privileged"
+	// LevelBaseline is a minimally-restrictive policy level that prevents
+	// known privilege escalations.
+	LevelBaseline Level = "baseline"
+	// LevelRestricted is a heavily-restricted policy level, following
+	// current Pod hardening best practices.
+	LevelRestricted Level = "restricted"
+	// LevelHardened sits above Restricted: it requires explicit,
+	// non-default values (runAsUser >= 1000, an explicit seccompProfile,
+	// readOnlyRootFilesystem=true, no added capabilities at all, no
+	// hostPort regardless of allow-lists) in places Restricted only
+	// forbids the worst case or leaves unset.
+	LevelHardened Level = "hardened"
+)
+
+// ParseLevel turns a policy level label value (as used in the
+// pod-security.kubernetes.io/enforce family of annotations, and in
+// --enforce-mode-style flags) into a Level, rejecting anything else.
+func ParseLevel(s string) (Level, error) {
+	switch Level(s) {
+	case LevelPrivileged, LevelBaseline, LevelRestricted, LevelHardened:
+		return Level(s), nil
+	default:
+		return "", fmt.Errorf("invalid level %q, valid values are %v", s, knownLevels())
+	}
+}
+
+func knownLevels() []Level {
+	return []Level{LevelPrivileged, LevelBaseline, LevelRestricted, LevelHardened}
+}
+
+func (l Level) String() string {
+	return string(l)
+}
@@ -0,0 +1,28 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package api defines the Level/Version vocabulary that the policy package's
+// checks are registered and selected against, independent of how those
+// checks are implemented.
+package api
+
+// LevelVersion pairs a Level with the Version it applies to: the unit
+// policy/patch.Patch and (eventually) policy.Evaluator select registered
+// checks by.
+type LevelVersion struct {
+	Level   Level
+	Version Version
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is the Kubernetes minor version a VersionedCheck.MinimumVersion is
+// pinned to, or the "latest" sentinel used by checks with no upper bound.
+type Version struct {
+	major  int
+	minor  int
+	latest bool
+}
+
+// MajorMinorVersion constructs a concrete major.minor Version, e.g.
+// MajorMinorVersion(1, 29) for v1.29.
+func MajorMinorVersion(major, minor int) Version {
+	return Version{major: major, minor: minor}
+}
+
+// LatestVersion returns the sentinel Version that is never Older than any
+// concrete major.minor Version, for checks that apply at whatever version
+// the binary was built against.
+func LatestVersion() Version {
+	return Version{latest: true}
+}
+
+// Older reports whether v is older than other. The latest sentinel is
+// never older than a concrete version, and a concrete version is always
+// older than latest.
+func (v Version) Older(other Version) bool {
+	if v.latest {
+		return false
+	}
+	if other.latest {
+		return true
+	}
+	if v.major != other.major {
+		return v.major < other.major
+	}
+	return v.minor < other.minor
+}
+
+func (v Version) String() string {
+	if v.latest {
+		return "latest"
+	}
+	return fmt.Sprintf("v%d.%d", v.major, v.minor)
+}
+
+// ParseVersion parses a "v1.29", "1.29", or "latest" string into a Version.
+func ParseVersion(s string) (Version, error) {
+	if s == "latest" {
+		return LatestVersion(), nil
+	}
+	major, minor, ok := strings.Cut(strings.TrimPrefix(s, "v"), ".")
+	if !ok {
+		return Version{}, fmt.Errorf("invalid version %q, expected \"vMAJOR.MINOR\" or \"latest\"", s)
+	}
+	majorNum, err := strconv.Atoi(major)
+	if err != nil {
+		return Version{}, fmt.Errorf("invalid version %q: %w", s, err)
+	}
+	minorNum, err := strconv.Atoi(minor)
+	if err != nil {
+		return Version{}, fmt.Errorf("invalid version %q: %w", s, err)
+	}
+	return MajorMinorVersion(majorNum, minorNum), nil
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command psa-scan offline-scans a Pod YAML manifest against Pod Security
+// Admission and prints the violations as SARIF (for GitHub's code scanning
+// tab) or as compact JSON.
+//
+// This package has no access to policy.Evaluator (the type that dispatches
+// a pod to every registered Check for a given level/version isn't part of
+// this checkout), so it drives policy.ChecksForLevel directly: every
+// VersionedCheck selected for the requested level/version runs its CheckPod
+// against the manifest, and each disallowed CheckResult becomes one
+// report.Result keyed by the check's own CheckID. Unlike running
+// policy/patch.Patch and reporting mutated fields, this sees every
+// violation CheckPod would reject, not only the ones a check knows how to
+// auto-remediate.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"sigs.k8s.io/yaml"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/pod-security-admission/api"
+	"k8s.io/pod-security-admission/policy"
+	"k8s.io/pod-security-admission/policy/report"
+)
+
+// scanVersion is the Kubernetes version this binary evaluates manifests
+// against, until a real --kube-version flag is wired to api.Version parsing.
+var scanVersion = api.MajorMinorVersion(1, 30)
+
+func main() {
+	manifest := flag.String("in", "", "path to a Pod YAML manifest")
+	level := flag.String("level", "restricted", "policy level to scan against: baseline or restricted")
+	format := flag.String("out", "sarif", "output format: sarif, json, or structured")
+	flag.Parse()
+
+	if *manifest == "" {
+		fmt.Fprintln(os.Stderr, "psa-scan: -in <manifest.yaml> is required")
+		os.Exit(2)
+	}
+
+	if err := run(*manifest, *level, *format, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "psa-scan: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(manifestPath, level, format string, out *os.File) error {
+	raw, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", manifestPath, err)
+	}
+
+	var pod corev1.Pod
+	if err := yaml.UnmarshalStrict(raw, &pod); err != nil {
+		return fmt.Errorf("parsing %s as a Pod: %w", manifestPath, err)
+	}
+
+	lv, err := levelVersion(level)
+	if err != nil {
+		return err
+	}
+
+	var results []report.Result
+	for _, check := range policy.ChecksForLevel(lv) {
+		result := check.CheckPod(&pod.ObjectMeta, &pod.Spec, policy.WithErrList())
+		if result.Allowed {
+			continue
+		}
+		results = append(results, report.Result{
+			CheckID:     check.ID,
+			Level:       check.Level,
+			Version:     check.MinimumVersion,
+			CheckResult: result,
+		})
+	}
+
+	var rendered []byte
+	switch format {
+	case "sarif":
+		rendered, err = report.FormatSARIF(&pod.ObjectMeta, results)
+	case "json":
+		rendered, err = report.FormatJSON(&pod.ObjectMeta, results)
+	case "structured":
+		rendered, err = report.FormatStructuredErrors(results)
+	default:
+		return fmt.Errorf("unknown -out format %q, want sarif, json, or structured", format)
+	}
+	if err != nil {
+		return fmt.Errorf("rendering results: %w", err)
+	}
+
+	_, err = out.Write(append(rendered, '\n'))
+	return err
+}
+
+func levelVersion(level string) (api.LevelVersion, error) {
+	switch level {
+	case "baseline":
+		return api.LevelVersion{Level: api.LevelBaseline, Version: scanVersion}, nil
+	case "restricted":
+		return api.LevelVersion{Level: api.LevelRestricted, Version: scanVersion}, nil
+	default:
+		return api.LevelVersion{}, fmt.Errorf("unknown -level %q, want baseline or restricted", level)
+	}
+}
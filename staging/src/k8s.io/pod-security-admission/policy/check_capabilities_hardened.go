@@ -0,0 +1,95 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/pod-security-admission/api"
+)
+
+/*
+Containers must not add any capabilities, including NET_BIND_SERVICE: a
+Hardened workload that needs a privileged port should rebind it via a sysctl
+or an unprivileged-port range instead.
+
+This check inspects only capabilities.add. It does not check
+capabilities.drop, and because it overrides capabilities_restricted, a
+capabilities.drop=["ALL"] requirement is not enforced at the Hardened level.
+
+**Restricted Fields:**
+spec.containers[*].securityContext.capabilities.add
+spec.initContainers[*].securityContext.capabilities.add
+
+**Allowed Values:** undefined/empty
+*/
+
+func init() {
+	addCheck(CheckCapabilitiesHardened)
+}
+
+// CheckCapabilitiesHardened returns a hardened level check that forbids any
+// added capability in 1.0+, tightening CheckCapabilitiesRestricted's
+// NET_BIND_SERVICE exception.
+func CheckCapabilitiesHardened() Check {
+	return Check{
+		ID:    "capabilities_hardened",
+		Level: api.LevelHardened,
+		Versions: []VersionedCheck{
+			{
+				MinimumVersion:   api.MajorMinorVersion(1, 0),
+				CheckPod:         withOptions(capabilitiesHardened_1_0),
+				OverrideCheckIDs: []CheckID{"capabilities_restricted"},
+			},
+		},
+	}
+}
+
+func capabilitiesHardened_1_0(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, opts options) CheckResult {
+	addedCapabilities := sets.NewString()
+	containersAddingCapabilities := violations[string]{withFieldErrors: opts.withFieldErrors}
+
+	visitContainers(podSpec, opts, func(container *corev1.Container, pathFn PathFn) {
+		if container.SecurityContext == nil || container.SecurityContext.Capabilities == nil || len(container.SecurityContext.Capabilities.Add) == 0 {
+			return
+		}
+		strSlice := make([]string, len(container.SecurityContext.Capabilities.Add))
+		for i, c := range container.SecurityContext.Capabilities.Add {
+			strSlice[i] = string(c)
+			addedCapabilities.Insert(string(c))
+		}
+		containersAddingCapabilities.Add(container.Name, forbidden(pathFn.child("securityContext").child("capabilities").child("add")).withBadValue(strSlice))
+	})
+
+	if !containersAddingCapabilities.Empty() {
+		return CheckResult{
+			Allowed:         false,
+			ForbiddenReason: "unrestricted capabilities",
+			ForbiddenDetail: fmt.Sprintf(
+				`%s %s must not set securityContext.capabilities.add; must not include %s`,
+				pluralize("container", "containers", containersAddingCapabilities.Len()),
+				joinQuote(containersAddingCapabilities.Data()),
+				strings.Join(addedCapabilities.List(), ", ")),
+			ErrList: containersAddingCapabilities.Errs(),
+		}
+	}
+	return CheckResult{Allowed: true}
+}
@@ -64,12 +64,14 @@ func CheckCapabilitiesRestricted() Check {
 			{
 				MinimumVersion:   api.MajorMinorVersion(1, 22),
 				CheckPod:         withOptions(capabilitiesRestricted_1_22),
+				PatchPod:         withPatchOptions(PatchCapabilitiesRestricted),
 				OverrideCheckIDs: []CheckID{checkCapabilitiesBaselineID},
 			},
 			// Starting 1.25, windows pods would be exempted from this check using pod.spec.os field when set to windows.
 			{
 				MinimumVersion:   api.MajorMinorVersion(1, 25),
 				CheckPod:         withOptions(capabilitiesRestricted_1_25),
+				PatchPod:         withPatchOptions(PatchCapabilitiesRestricted),
 				OverrideCheckIDs: []CheckID{checkCapabilitiesBaselineID},
 			},
 		},
@@ -78,12 +80,12 @@ func CheckCapabilitiesRestricted() Check {
 
 func capabilitiesRestricted_1_22(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, opts options) CheckResult {
 	var forbiddenCapabilities = sets.NewString()
-	var containersMissingDropAll violations[string]
-	var containersAddingForbidden violations[string]
+	containersMissingDropAll := violations[string]{withFieldErrors: opts.withFieldErrors}
+	containersAddingForbidden := violations[string]{withFieldErrors: opts.withFieldErrors}
 
-	visitContainersWithPath(podSpec, func(container *corev1.Container, pathFn PathFn) {
+	visitContainers(podSpec, opts, func(container *corev1.Container, pathFn PathFn) {
 		if container.SecurityContext == nil || container.SecurityContext.Capabilities == nil {
-			containersMissingDropAll.Add(container.Name, opts, required(pathFn.child("securityContext").child("capabilities").child("drop")))
+			containersMissingDropAll.Add(container.Name, required(pathFn.child("securityContext").child("capabilities").child("drop")))
 			return
 		}
 
@@ -99,18 +101,18 @@ func capabilitiesRestricted_1_22(podMetadata *metav1.ObjectMeta, podSpec *corev1
 			for i, v := range container.SecurityContext.Capabilities.Drop {
 				strSlice[i] = string(v)
 			}
-			containersMissingDropAll.Add(container.Name, opts, forbidden(pathFn.child("securityContext").child("capabilities").child("drop"), strSlice))
+			containersMissingDropAll.Add(container.Name, forbidden(pathFn.child("securityContext").child("capabilities").child("drop")).withBadValue(strSlice))
 		}
 
 		addedForbidden := false
 		for _, c := range container.SecurityContext.Capabilities.Add {
-			if c != capabilityNetBindService {
+			if c != capabilityNetBindService && !opts.allowedAddCapabilities.Has(string(c)) {
 				addedForbidden = true
 				forbiddenCapabilities.Insert(string(c))
 			}
 		}
 		if addedForbidden {
-			containersAddingForbidden.Add(container.Name, opts, forbidden(pathFn.child("securityContext").child("capabilities").child("add"), forbiddenCapabilities.List()))
+			containersAddingForbidden.Add(container.Name, forbidden(pathFn.child("securityContext").child("capabilities").child("add")).withBadValue(forbiddenCapabilities.List()))
 		}
 	})
 
@@ -123,11 +125,16 @@ func capabilitiesRestricted_1_22(podMetadata *metav1.ObjectMeta, podSpec *corev1
 			joinQuote(containersMissingDropAll.Data())))
 	}
 	if !containersAddingForbidden.Empty() {
+		allowedNote := ""
+		if len(opts.allowedAddCapabilities) > 0 {
+			allowedNote = " (outside the configured allowed capabilities)"
+		}
 		forbiddenDetails = append(forbiddenDetails, fmt.Sprintf(
-			`%s %s must not include %s in securityContext.capabilities.add`,
+			`%s %s must not include %s in securityContext.capabilities.add%s`,
 			pluralize("container", "containers", containersAddingForbidden.Len()),
 			joinQuote(containersAddingForbidden.Data()),
-			joinQuote(forbiddenCapabilities.List())))
+			joinQuote(forbiddenCapabilities.List()),
+			allowedNote))
 	}
 	if len(forbiddenDetails) > 0 {
 		return CheckResult{
@@ -148,3 +155,59 @@ func capabilitiesRestricted_1_25(podMetadata *metav1.ObjectMeta, podSpec *corev1
 	}
 	return capabilitiesRestricted_1_22(podMetadata, podSpec, opts)
 }
+
+// PatchCapabilitiesRestricted mutates every container to satisfy
+// CheckCapabilitiesRestricted: securityContext.capabilities.drop is set to
+// ["ALL"] if not already present, and any added capability other than
+// NET_BIND_SERVICE or one of opts.allowedAddCapabilities is stripped. It is
+// the PatchPod counterpart to CheckCapabilitiesRestricted, for use by the
+// policy/patch auto-remediation mode.
+func PatchCapabilitiesRestricted(podSpec *corev1.PodSpec, opts options) []PatchedField {
+	var changed []PatchedField
+
+	patchContainer := func(container *corev1.Container, pathFn PathFn) {
+		if container.SecurityContext == nil {
+			container.SecurityContext = &corev1.SecurityContext{}
+		}
+		if container.SecurityContext.Capabilities == nil {
+			container.SecurityContext.Capabilities = &corev1.Capabilities{}
+		}
+		caps := container.SecurityContext.Capabilities
+
+		droppedAll := false
+		for _, c := range caps.Drop {
+			if c == capabilityAll {
+				droppedAll = true
+				break
+			}
+		}
+		if !droppedAll {
+			caps.Drop = append(caps.Drop, capabilityAll)
+			changed = append(changed, PatchedField{
+				Field:  fmt.Sprintf("container %q securityContext.capabilities.drop", container.Name),
+				Detail: `set capabilities.drop=["ALL"]`,
+			})
+		}
+
+		var kept []corev1.Capability
+		strippedAny := false
+		for _, c := range caps.Add {
+			if c == capabilityNetBindService || opts.allowedAddCapabilities.Has(string(c)) {
+				kept = append(kept, c)
+			} else {
+				strippedAny = true
+			}
+		}
+		if strippedAny {
+			caps.Add = kept
+			changed = append(changed, PatchedField{
+				Field:  fmt.Sprintf("container %q securityContext.capabilities.add", container.Name),
+				Detail: "stripped disallowed capabilities.add entries",
+			})
+		}
+	}
+
+	visitContainers(podSpec, opts, patchContainer)
+
+	return changed
+}
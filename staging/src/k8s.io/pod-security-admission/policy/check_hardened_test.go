@@ -0,0 +1,178 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestCapabilitiesHardened(t *testing.T) {
+	tests := []struct {
+		name    string
+		pod     *corev1.Pod
+		allowed bool
+	}{
+		{
+			name: "NET_BIND_SERVICE is forbidden, unlike Restricted",
+			pod: &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Name: "a",
+				SecurityContext: &corev1.SecurityContext{
+					Capabilities: &corev1.Capabilities{Add: []corev1.Capability{"NET_BIND_SERVICE"}},
+				},
+			}}}},
+			allowed: false,
+		},
+		{
+			name: "no added capabilities is allowed",
+			pod: &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Name: "a",
+				SecurityContext: &corev1.SecurityContext{
+					Capabilities: &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+				},
+			}}}},
+			allowed: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result := capabilitiesHardened_1_0(&tc.pod.ObjectMeta, &tc.pod.Spec, options{})
+			if result.Allowed != tc.allowed {
+				t.Errorf("expected Allowed=%v, got Allowed=%v (%s: %s)", tc.allowed, result.Allowed, result.ForbiddenReason, result.ForbiddenDetail)
+			}
+		})
+	}
+}
+
+func TestSeccompHardened(t *testing.T) {
+	tests := []struct {
+		name    string
+		pod     *corev1.Pod
+		allowed bool
+	}{
+		{
+			name:    "undefined seccompProfile is forbidden, unlike Baseline",
+			pod:     &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "a"}}}},
+			allowed: false,
+		},
+		{
+			name: "pod-level RuntimeDefault covers all containers",
+			pod: &corev1.Pod{Spec: corev1.PodSpec{
+				SecurityContext: &corev1.PodSecurityContext{
+					SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+				},
+				Containers: []corev1.Container{{Name: "a"}},
+			}},
+			allowed: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result := seccompHardened_1_0(&tc.pod.ObjectMeta, &tc.pod.Spec, options{})
+			if result.Allowed != tc.allowed {
+				t.Errorf("expected Allowed=%v, got Allowed=%v (%s: %s)", tc.allowed, result.Allowed, result.ForbiddenReason, result.ForbiddenDetail)
+			}
+		})
+	}
+}
+
+func TestRunAsUserHardened(t *testing.T) {
+	low := int64(999)
+	high := int64(1000)
+	tests := []struct {
+		name    string
+		pod     *corev1.Pod
+		allowed bool
+	}{
+		{
+			name:    "unset runAsUser is forbidden, unlike Restricted",
+			pod:     &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "a"}}}},
+			allowed: false,
+		},
+		{
+			name: "runAsUser below 1000 is forbidden",
+			pod: &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Name:            "a",
+				SecurityContext: &corev1.SecurityContext{RunAsUser: &low},
+			}}}},
+			allowed: false,
+		},
+		{
+			name: "runAsUser=1000 at the pod level covers all containers",
+			pod: &corev1.Pod{Spec: corev1.PodSpec{
+				SecurityContext: &corev1.PodSecurityContext{RunAsUser: &high},
+				Containers:      []corev1.Container{{Name: "a"}},
+			}},
+			allowed: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result := runAsUserHardened_1_0(&tc.pod.ObjectMeta, &tc.pod.Spec, options{})
+			if result.Allowed != tc.allowed {
+				t.Errorf("expected Allowed=%v, got Allowed=%v (%s: %s)", tc.allowed, result.Allowed, result.ForbiddenReason, result.ForbiddenDetail)
+			}
+		})
+	}
+}
+
+func TestReadOnlyRootFilesystemHardened(t *testing.T) {
+	yes := true
+	tests := []struct {
+		name    string
+		pod     *corev1.Pod
+		allowed bool
+	}{
+		{
+			name:    "unset readOnlyRootFilesystem is forbidden",
+			pod:     &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "a"}}}},
+			allowed: false,
+		},
+		{
+			name: "readOnlyRootFilesystem=true is allowed",
+			pod: &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Name:            "a",
+				SecurityContext: &corev1.SecurityContext{ReadOnlyRootFilesystem: &yes},
+			}}}},
+			allowed: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result := readOnlyRootFilesystemHardened_1_0(&tc.pod.ObjectMeta, &tc.pod.Spec, options{})
+			if result.Allowed != tc.allowed {
+				t.Errorf("expected Allowed=%v, got Allowed=%v (%s: %s)", tc.allowed, result.Allowed, result.ForbiddenReason, result.ForbiddenDetail)
+			}
+		})
+	}
+}
+
+func TestHostPortsHardenedIgnoresAllowlist(t *testing.T) {
+	pod := &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+		Name:  "a",
+		Ports: []corev1.ContainerPort{{HostPort: 8080}},
+	}}}}
+
+	// The same allowlist that would permit this port under CheckHostPorts
+	// must not be honored by the hardened variant.
+	opts := options{allowedHostPorts: []PortRange{{Min: 8000, Max: 9000}}}
+	result := hostPortsHardened_1_0(&pod.ObjectMeta, &pod.Spec, opts)
+	if result.Allowed {
+		t.Errorf("expected Allowed=false, got Allowed=true")
+	}
+}
@@ -53,23 +53,49 @@ func CheckHostPorts() Check {
 			{
 				MinimumVersion: api.MajorMinorVersion(1, 0),
 				CheckPod:       withOptions(hostPorts_1_0),
+				PatchPod:       withPatchOptions(PatchHostPorts),
 			},
 		},
 	}
 }
 
+// PortRange is an inclusive range of ports, used to configure
+// CheckHostPorts allowlists via WithAllowedHostPorts.
+type PortRange struct {
+	Min int32
+	Max int32
+}
+
+// Contains reports whether port falls within the (inclusive) range.
+func (r PortRange) Contains(port int32) bool {
+	return port >= r.Min && port <= r.Max
+}
+
+func hostPortAllowed(port int32, allowed []PortRange) bool {
+	for _, r := range allowed {
+		if r.Contains(port) {
+			return true
+		}
+	}
+	return false
+}
+
 func hostPorts_1_0(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, opts options) CheckResult {
 	var badContainers []string
 	var errList field.ErrorList
 	forbiddenHostPorts := sets.NewString()
-	visitContainersWithPath(podSpec, func(container *corev1.Container, path *field.Path) {
+	visitContainers(podSpec, opts, func(container *corev1.Container, pathFn PathFn) {
 		valid := true
 		for i, c := range container.Ports {
-			if c.HostPort != 0 {
+			if c.HostPort != 0 && !hostPortAllowed(c.HostPort, opts.allowedHostPorts) {
 				valid = false
 				forbiddenHostPorts.Insert(strconv.Itoa(int(c.HostPort)))
 				opts.errListHandler(func() {
-					err := withBadValue(field.Forbidden(path.Child("ports").Index(i).Child("hostPort"), ""), []string{
+					path := pathFn.child("ports").index(i).child("hostPort").resolve()
+					if path == nil {
+						return
+					}
+					err := withBadValue(field.Forbidden(path, ""), []string{
 						strconv.Itoa(int(c.HostPort)),
 					})
 					errList = append(errList, err)
@@ -82,19 +108,45 @@ func hostPorts_1_0(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, opts
 	})
 
 	if len(badContainers) > 0 {
+		detail := fmt.Sprintf(
+			"%s %s %s %s %s",
+			pluralize("container", "containers", len(badContainers)),
+			joinQuote(badContainers),
+			pluralize("uses", "use", len(badContainers)),
+			pluralize("hostPort", "hostPorts", len(forbiddenHostPorts)),
+			strings.Join(forbiddenHostPorts.List(), ", "),
+		)
+		if len(opts.allowedHostPorts) > 0 {
+			detail += " outside the configured allowed hostPort ranges"
+		}
 		return CheckResult{
 			Allowed:         false,
 			ForbiddenReason: "hostPort",
-			ForbiddenDetail: fmt.Sprintf(
-				"%s %s %s %s %s",
-				pluralize("container", "containers", len(badContainers)),
-				joinQuote(badContainers),
-				pluralize("uses", "use", len(badContainers)),
-				pluralize("hostPort", "hostPorts", len(forbiddenHostPorts)),
-				strings.Join(forbiddenHostPorts.List(), ", "),
-			),
-			ErrList: errList,
+			ForbiddenDetail: detail,
+			ErrList:         errList,
 		}
 	}
 	return CheckResult{Allowed: true}
 }
+
+// PatchHostPorts zeroes any hostPort set on a container's ports that isn't
+// covered by opts.allowedHostPorts, satisfying CheckHostPorts. It is the
+// PatchPod counterpart to CheckHostPorts, for use by the policy/patch
+// auto-remediation mode.
+func PatchHostPorts(podSpec *corev1.PodSpec, opts options) []PatchedField {
+	var changed []PatchedField
+
+	visitContainers(podSpec, opts, func(container *corev1.Container, pathFn PathFn) {
+		for i := range container.Ports {
+			if container.Ports[i].HostPort != 0 && !hostPortAllowed(container.Ports[i].HostPort, opts.allowedHostPorts) {
+				container.Ports[i].HostPort = 0
+				changed = append(changed, PatchedField{
+					Field:  fmt.Sprintf("container %q ports[%d].hostPort", container.Name, i),
+					Detail: "zeroed disallowed hostPort",
+				})
+			}
+		}
+	})
+
+	return changed
+}
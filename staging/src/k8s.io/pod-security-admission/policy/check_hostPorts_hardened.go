@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/pod-security-admission/api"
+)
+
+/*
+HostPort ports must be forbidden, with no operator-configured allowlist:
+CheckHostPorts honors opts.allowedHostPorts so operators can migrate
+specific workloads off hostNetwork gradually, but Hardened is the level
+those exceptions don't apply at.
+
+**Restricted Fields:**
+spec.containers[*].ports[*].hostPort
+spec.initContainers[*].ports[*].hostPort
+
+**Allowed Values:** undefined/0
+*/
+
+func init() {
+	addCheck(CheckHostPortsHardened)
+}
+
+// CheckHostPortsHardened returns a hardened level check that forbids any
+// hostPort in 1.0+, ignoring opts.allowedHostPorts unlike CheckHostPorts.
+func CheckHostPortsHardened() Check {
+	return Check{
+		ID:    "hostPorts_hardened",
+		Level: api.LevelHardened,
+		Versions: []VersionedCheck{
+			{
+				MinimumVersion:   api.MajorMinorVersion(1, 0),
+				CheckPod:         withOptions(hostPortsHardened_1_0),
+				OverrideCheckIDs: []CheckID{"hostPorts"},
+			},
+		},
+	}
+}
+
+func hostPortsHardened_1_0(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, opts options) CheckResult {
+	var badContainers []string
+	var errList field.ErrorList
+	forbiddenHostPorts := sets.NewString()
+
+	visitContainers(podSpec, opts, func(container *corev1.Container, pathFn PathFn) {
+		valid := true
+		for i, c := range container.Ports {
+			if c.HostPort != 0 {
+				valid = false
+				forbiddenHostPorts.Insert(strconv.Itoa(int(c.HostPort)))
+				opts.errListHandler(func() {
+					path := pathFn.child("ports").index(i).child("hostPort").resolve()
+					if path == nil {
+						return
+					}
+					err := withBadValue(field.Forbidden(path, ""), []string{
+						strconv.Itoa(int(c.HostPort)),
+					})
+					errList = append(errList, err)
+				})
+			}
+		}
+		if !valid {
+			badContainers = append(badContainers, container.Name)
+		}
+	})
+
+	if len(badContainers) > 0 {
+		return CheckResult{
+			Allowed:         false,
+			ForbiddenReason: "hostPort",
+			ForbiddenDetail: fmt.Sprintf(
+				"%s %s %s %s %s",
+				pluralize("container", "containers", len(badContainers)),
+				joinQuote(badContainers),
+				pluralize("uses", "use", len(badContainers)),
+				pluralize("hostPort", "hostPorts", len(forbiddenHostPorts)),
+				strings.Join(forbiddenHostPorts.List(), ", "),
+			),
+			ErrList: errList,
+		}
+	}
+	return CheckResult{Allowed: true}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/pod-security-admission/api"
+)
+
+/*
+Containers must set readOnlyRootFilesystem=true.
+
+**Restricted Fields:**
+spec.containers[*].securityContext.readOnlyRootFilesystem
+spec.initContainers[*].securityContext.readOnlyRootFilesystem
+
+**Allowed Values:** true
+*/
+
+func init() {
+	addCheck(CheckReadOnlyRootFilesystemHardened)
+}
+
+// CheckReadOnlyRootFilesystemHardened returns a hardened level check that
+// requires every container to set readOnlyRootFilesystem=true in 1.0+.
+// Neither Baseline nor Restricted constrain this field today, so there is
+// no prior check to override.
+func CheckReadOnlyRootFilesystemHardened() Check {
+	return Check{
+		ID:    "readOnlyRootFilesystem_hardened",
+		Level: api.LevelHardened,
+		Versions: []VersionedCheck{
+			{
+				MinimumVersion: api.MajorMinorVersion(1, 0),
+				CheckPod:       withOptions(readOnlyRootFilesystemHardened_1_0),
+			},
+		},
+	}
+}
+
+func readOnlyRootFilesystemHardened_1_0(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, opts options) CheckResult {
+	var badContainers []string
+	var errList field.ErrorList
+
+	visitContainers(podSpec, opts, func(container *corev1.Container, pathFn PathFn) {
+		if container.SecurityContext == nil || container.SecurityContext.ReadOnlyRootFilesystem == nil || !*container.SecurityContext.ReadOnlyRootFilesystem {
+			badContainers = append(badContainers, container.Name)
+			opts.errListHandler(func() {
+				path := pathFn.child("securityContext").child("readOnlyRootFilesystem").resolve()
+				if path == nil {
+					return
+				}
+				var badValue interface{}
+				if container.SecurityContext != nil {
+					badValue = container.SecurityContext.ReadOnlyRootFilesystem
+				}
+				errList = append(errList, withBadValue(field.Forbidden(path, ""), badValue))
+			})
+		}
+	})
+
+	if len(badContainers) > 0 {
+		return CheckResult{
+			Allowed:         false,
+			ForbiddenReason: "readOnlyRootFilesystem",
+			ForbiddenDetail: fmt.Sprintf(
+				"%s %s must set securityContext.readOnlyRootFilesystem=true",
+				pluralize("container", "containers", len(badContainers)),
+				joinQuote(badContainers)),
+			ErrList: errList,
+		}
+	}
+
+	return CheckResult{Allowed: true}
+}
@@ -91,200 +91,40 @@ func restrictedVolumes_1_0(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSp
 	badVolumeTypes := sets.NewString()
 
 	for i, volume := range podSpec.Volumes {
-		switch {
-		case volume.ConfigMap != nil,
-			volume.CSI != nil,
-			volume.DownwardAPI != nil,
-			volume.EmptyDir != nil,
-			volume.Ephemeral != nil,
-			volume.PersistentVolumeClaim != nil,
-			volume.Projected != nil,
-			volume.Secret != nil:
+		volume := volume
+		name := "unknown"
+		var allowed bool
+		var checkerErr *field.Error
+		if checker := matchVolumeTypeChecker(&volume); checker != nil {
+			name = checker.Name()
+			allowed, checkerErr = checker.Allowed(&volume, opts)
+		}
+		if allowed {
 			continue
+		}
 
-		default:
-			badVolumes = append(badVolumes, volume.Name)
-			volumesIndexPath := volumesPath.Index(i)
-
-			switch {
-			case volume.HostPath != nil:
-				badVolumeTypes.Insert("hostPath")
-				opts.errListHandler(func() {
-					err := withBadValue(field.Forbidden(volumesIndexPath, ""), []string{
-						volumesIndexPath.Child("hostPath").String(),
-					})
-					errList = append(errList, err)
-				})
-			case volume.GCEPersistentDisk != nil:
-				badVolumeTypes.Insert("gcePersistentDisk")
-				opts.errListHandler(func() {
-					err := withBadValue(field.Forbidden(volumesIndexPath, ""), []string{
-						volumesIndexPath.Child("gcePersistentDisk").String(),
-					})
-					errList = append(errList, err)
-				})
-			case volume.AWSElasticBlockStore != nil:
-				badVolumeTypes.Insert("awsElasticBlockStore")
-				opts.errListHandler(func() {
-					err := withBadValue(field.Forbidden(volumesIndexPath, ""), []string{
-						volumesIndexPath.Child("awsElasticBlockStore").String(),
-					})
-					errList = append(errList, err)
-				})
-			case volume.GitRepo != nil:
-				badVolumeTypes.Insert("gitRepo")
-				opts.errListHandler(func() {
-					err := withBadValue(field.Forbidden(volumesIndexPath, ""), []string{
-						volumesIndexPath.Child("gitRepo").String(),
-					})
-					errList = append(errList, err)
-				})
-			case volume.NFS != nil:
-				badVolumeTypes.Insert("nfs")
-				opts.errListHandler(func() {
-					err := withBadValue(field.Forbidden(volumesIndexPath, ""), []string{
-						volumesIndexPath.Child("gitRepo").String(),
-					})
-					errList = append(errList, err)
-				})
-			case volume.ISCSI != nil:
-				badVolumeTypes.Insert("iscsi")
-				opts.errListHandler(func() {
-					err := withBadValue(field.Forbidden(volumesIndexPath, ""), []string{
-						volumesIndexPath.Child("iscsi").String(),
-					})
-					errList = append(errList, err)
-				})
-			case volume.Glusterfs != nil:
-				badVolumeTypes.Insert("glusterfs")
-				opts.errListHandler(func() {
-					err := withBadValue(field.Forbidden(volumesIndexPath, ""), []string{
-						volumesIndexPath.Child("glusterfs").String(),
-					})
-					errList = append(errList, err)
-				})
-			case volume.RBD != nil:
-				badVolumeTypes.Insert("rbd")
-				opts.errListHandler(func() {
-					err := withBadValue(field.Forbidden(volumesIndexPath, ""), []string{
-						volumesIndexPath.Child("rbd").String(),
-					})
-					errList = append(errList, err)
-				})
-			case volume.FlexVolume != nil:
-				badVolumeTypes.Insert("flexVolume")
-				opts.errListHandler(func() {
-					err := withBadValue(field.Forbidden(volumesIndexPath, ""), []string{
-						volumesIndexPath.Child("flexVolume").String(),
-					})
-					errList = append(errList, err)
-				})
-			case volume.Cinder != nil:
-				badVolumeTypes.Insert("cinder")
-				opts.errListHandler(func() {
-					err := withBadValue(field.Forbidden(volumesIndexPath, ""), []string{
-						volumesIndexPath.Child("cinder").String(),
-					})
-					errList = append(errList, err)
-				})
-			case volume.CephFS != nil:
-				badVolumeTypes.Insert("cephfs")
-				opts.errListHandler(func() {
-					err := withBadValue(field.Forbidden(volumesIndexPath, ""), []string{
-						volumesIndexPath.Child("cephfs").String(),
-					})
-					errList = append(errList, err)
-				})
-			case volume.Flocker != nil:
-				badVolumeTypes.Insert("flocker")
-				opts.errListHandler(func() {
-					err := withBadValue(field.Forbidden(volumesIndexPath, ""), []string{
-						volumesIndexPath.Child("flocker").String(),
-					})
-					errList = append(errList, err)
-				})
-			case volume.FC != nil:
-				badVolumeTypes.Insert("fc")
-				opts.errListHandler(func() {
-					err := withBadValue(field.Forbidden(volumesIndexPath, ""), []string{
-						volumesIndexPath.Child("fc").String(),
-					})
-					errList = append(errList, err)
-				})
-			case volume.AzureFile != nil:
-				badVolumeTypes.Insert("azureFile")
-				opts.errListHandler(func() {
-					err := withBadValue(field.Forbidden(volumesIndexPath, ""), []string{
-						volumesIndexPath.Child("azureFile").String(),
-					})
-					errList = append(errList, err)
-				})
-			case volume.VsphereVolume != nil:
-				badVolumeTypes.Insert("vsphereVolume")
-				opts.errListHandler(func() {
-					err := withBadValue(field.Forbidden(volumesIndexPath, ""), []string{
-						volumesIndexPath.Child("vsphereVolume").String(),
-					})
-					errList = append(errList, err)
-				})
-			case volume.Quobyte != nil:
-				badVolumeTypes.Insert("quobyte")
-				opts.errListHandler(func() {
-					err := withBadValue(field.Forbidden(volumesIndexPath, ""), []string{
-						volumesIndexPath.Child("quobyte").String(),
-					})
-					errList = append(errList, err)
-				})
-			case volume.AzureDisk != nil:
-				badVolumeTypes.Insert("azureDisk")
-				opts.errListHandler(func() {
-					err := withBadValue(field.Forbidden(volumesIndexPath, ""), []string{
-						volumesIndexPath.Child("azureDisk").String(),
-					})
-					errList = append(errList, err)
-				})
-			case volume.PhotonPersistentDisk != nil:
-				badVolumeTypes.Insert("photonPersistentDisk")
-				opts.errListHandler(func() {
-					err := withBadValue(field.Forbidden(volumesIndexPath, ""), []string{
-						volumesIndexPath.Child("photonPersistentDisk").String(),
-					})
-					errList = append(errList, err)
-				})
-			case volume.PortworxVolume != nil:
-				badVolumeTypes.Insert("portworxVolume")
-				opts.errListHandler(func() {
-					err := withBadValue(field.Forbidden(volumesIndexPath, ""), []string{
-						volumesIndexPath.Child("portworxVolume").String(),
-					})
-					errList = append(errList, err)
-				})
-			case volume.ScaleIO != nil:
-				badVolumeTypes.Insert("scaleIO")
-				opts.errListHandler(func() {
-					err := withBadValue(field.Forbidden(volumesIndexPath, ""), []string{
-						volumesIndexPath.Child("scaleIO").String(),
-					})
-					errList = append(errList, err)
-				})
-			case volume.StorageOS != nil:
-				badVolumeTypes.Insert("storageos")
-				opts.errListHandler(func() {
-					err := withBadValue(field.Forbidden(volumesIndexPath, ""), []string{
-						volumesIndexPath.Child("storageos").String(),
-					})
-					errList = append(errList, err)
-				})
-			default:
-				badVolumeTypes.Insert("unknown")
-				opts.errListHandler(func() {
-					err := withBadValue(field.Forbidden(volumesIndexPath, ""), []string{
-						volumesIndexPath.Child("unknown").String(),
-					})
-					errList = append(errList, err)
-				})
+		badVolumes = append(badVolumes, volume.Name)
+		badVolumeTypes.Insert(name)
+		volumesIndexPath := volumesPath.index(i)
+		opts.errListHandler(func() {
+			path := volumesIndexPath()
+			if path == nil {
+				return
 			}
-		}
+			if checkerErr != nil {
+				// The checker built its own field.Error (e.g. pointing at
+				// csi.driver rather than the volume as a whole); root it
+				// under this volume's index instead of discarding it.
+				rooted := *checkerErr
+				rooted.Field = path.Child(rooted.Field).String()
+				errList = append(errList, &rooted)
+				return
+			}
+			err := withBadValue(field.Forbidden(path, ""), []string{
+				path.Child(name).String(),
+			})
+			errList = append(errList, err)
+		})
 	}
 
 	if len(badVolumes) > 0 {
@@ -0,0 +1,301 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/pod-security-admission/api"
+)
+
+// restrictedVolumesSafeTypes are the volume types CheckRestrictedVolumes
+// always allows. CheckRestrictedVolumesPolicy defaults to allowing them too,
+// but only as a fallback when no VolumePolicy rule matches: an explicit
+// "deny" rule matching one of these types still denies it, so a policy can
+// tighten the restricted profile's default allow-list, not just widen it.
+var restrictedVolumesSafeTypes = sets.NewString(
+	"configMap", "csi", "downwardAPI", "emptyDir", "ephemeral",
+	"persistentVolumeClaim", "projected", "secret",
+)
+
+// VolumePolicy is a declarative, YAML/JSON-loadable volume policy: an
+// ordered list of rules, each matching volumes by type and/or by the
+// namespace/pod/storage class they're used from, and either allowing or
+// denying them. It's compiled with CompileVolumePolicy and installed with
+// WithVolumePolicy, and is evaluated by CheckRestrictedVolumesPolicy in
+// place of CheckRestrictedVolumes' fixed allow-list, similar in spirit to
+// Velero's resource policies.
+type VolumePolicy struct {
+	// Version must be "v1".
+	Version string `json:"version"`
+	// VolumePolicies are evaluated in order against every volume, including
+	// restrictedVolumesSafeTypes members; the first rule whose Conditions
+	// match wins. A volume matching no rule is allowed if its type is in
+	// restrictedVolumesSafeTypes and denied otherwise, same as
+	// CheckRestrictedVolumes today.
+	VolumePolicies []VolumePolicyRule `json:"volumePolicies"`
+}
+
+// VolumePolicyRule is a single entry of a VolumePolicy.
+type VolumePolicyRule struct {
+	Conditions VolumePolicyConditions `json:"conditions"`
+	Action     VolumePolicyAction     `json:"action"`
+}
+
+// VolumePolicyAction is the outcome of a matched VolumePolicyRule.
+type VolumePolicyAction string
+
+const (
+	VolumePolicyActionAllow VolumePolicyAction = "allow"
+	VolumePolicyActionDeny  VolumePolicyAction = "deny"
+)
+
+// VolumePolicyConditions are ANDed together to decide whether a
+// VolumePolicyRule applies to a given volume. An empty/nil condition is
+// ignored rather than treated as "match nothing".
+type VolumePolicyConditions struct {
+	// VolumeTypes restricts the rule to the given volume type names (e.g.
+	// "nfs", "csi" — the same names CheckRestrictedVolumes reports in its
+	// ForbiddenDetail). Empty matches any volume type.
+	VolumeTypes []string `json:"volumeTypes,omitempty"`
+	// NamespaceSelector restricts the rule to pods in a namespace matching
+	// the selector. Requires the caller to supply WithNamespaceLabels;
+	// without it, a rule with a NamespaceSelector never matches.
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// PodSelector restricts the rule to pods matching the selector against
+	// the pod's own labels.
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+	// StorageClassNames restricts the rule to persistentVolumeClaim volumes
+	// whose claim resolves (via WithPersistentVolumeClaimStorageClassLookup)
+	// to one of the given storage class names. Without a lookup installed,
+	// a rule with StorageClassNames never matches.
+	StorageClassNames []string `json:"storageClassNames,omitempty"`
+}
+
+// PersistentVolumeClaimStorageClassLookup resolves the spec.storageClassName
+// of a namespaced PersistentVolumeClaim referenced by name. CheckPod only
+// ever sees the Pod being admitted, not the PersistentVolumeClaim objects it
+// references, so a VolumePolicy rule with a StorageClassNames condition can
+// only match inline persistentVolumeClaim volumes if the caller supplies a
+// lookup here, e.g. backed by a PVC informer.
+type PersistentVolumeClaimStorageClassLookup func(namespace, claimName string) (storageClass string, ok bool)
+
+// CompiledVolumePolicy is a VolumePolicy compiled into an ordered set of
+// predicates, ready to be installed with WithVolumePolicy. Build one with
+// CompileVolumePolicy.
+type CompiledVolumePolicy struct {
+	rules []compiledVolumePolicyRule
+}
+
+type compiledVolumePolicyRule struct {
+	volumeTypes       sets.String
+	storageClassNames sets.String
+	namespaceSelector labels.Selector
+	podSelector       labels.Selector
+	action            VolumePolicyAction
+}
+
+// CompileVolumePolicy validates policy and compiles its rules' selectors,
+// returning an error that names the offending rule if anything is invalid.
+// A nil policy compiles to a nil *CompiledVolumePolicy.
+func CompileVolumePolicy(policy *VolumePolicy) (*CompiledVolumePolicy, error) {
+	if policy == nil {
+		return nil, nil
+	}
+	if policy.Version != "v1" {
+		return nil, fmt.Errorf("unsupported volume policy version %q, expected \"v1\"", policy.Version)
+	}
+
+	compiled := &CompiledVolumePolicy{rules: make([]compiledVolumePolicyRule, 0, len(policy.VolumePolicies))}
+	for i, rule := range policy.VolumePolicies {
+		switch rule.Action {
+		case VolumePolicyActionAllow, VolumePolicyActionDeny:
+		default:
+			return nil, fmt.Errorf("volumePolicies[%d]: unknown action %q", i, rule.Action)
+		}
+
+		compiledRule := compiledVolumePolicyRule{
+			volumeTypes:       sets.NewString(rule.Conditions.VolumeTypes...),
+			storageClassNames: sets.NewString(rule.Conditions.StorageClassNames...),
+			action:            rule.Action,
+		}
+		if rule.Conditions.NamespaceSelector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(rule.Conditions.NamespaceSelector)
+			if err != nil {
+				return nil, fmt.Errorf("volumePolicies[%d]: namespaceSelector: %w", i, err)
+			}
+			compiledRule.namespaceSelector = selector
+		}
+		if rule.Conditions.PodSelector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(rule.Conditions.PodSelector)
+			if err != nil {
+				return nil, fmt.Errorf("volumePolicies[%d]: podSelector: %w", i, err)
+			}
+			compiledRule.podSelector = selector
+		}
+		compiled.rules = append(compiled.rules, compiledRule)
+	}
+	return compiled, nil
+}
+
+// action returns the action of the first rule matching volumeType, and
+// whether any rule matched at all.
+func (c *CompiledVolumePolicy) action(volumeType, storageClass string, hasStorageClass bool, podLabels, namespaceLabels labels.Labels) (VolumePolicyAction, bool) {
+	for _, rule := range c.rules {
+		if rule.volumeTypes.Len() > 0 && !rule.volumeTypes.Has(volumeType) {
+			continue
+		}
+		if rule.storageClassNames.Len() > 0 && (!hasStorageClass || !rule.storageClassNames.Has(storageClass)) {
+			continue
+		}
+		if rule.namespaceSelector != nil && !rule.namespaceSelector.Matches(namespaceLabels) {
+			continue
+		}
+		if rule.podSelector != nil && !rule.podSelector.Matches(podLabels) {
+			continue
+		}
+		return rule.action, true
+	}
+	return "", false
+}
+
+// volumeStorageClass resolves the storage class of volume via
+// opts.pvcStorageClassLookup, if volume is an inline persistentVolumeClaim
+// volume and a lookup is configured.
+func (o options) volumeStorageClass(namespace string, volume corev1.Volume) (string, bool) {
+	if volume.PersistentVolumeClaim == nil || o.pvcStorageClassLookup == nil {
+		return "", false
+	}
+	return o.pvcStorageClassLookup(namespace, volume.PersistentVolumeClaim.ClaimName)
+}
+
+/*
+In addition to restricting HostPath volumes, the restricted profile limits
+usage of inline pod volume sources to the types listed in
+restrictedVolumesSafeTypes, unless a configured VolumePolicy (see
+WithVolumePolicy) allows a specific volume type, namespace, pod, or storage
+class exception.
+
+**Restricted Fields:**
+
+spec.volumes[*]
+
+**Allowed Values:** one of restrictedVolumesSafeTypes, or allowed by the
+configured VolumePolicy
+*/
+
+func init() {
+	addCheck(CheckRestrictedVolumesPolicy)
+}
+
+// CheckRestrictedVolumesPolicy returns a restricted level check that
+// replaces CheckRestrictedVolumes' fixed volume-type allow-list with an
+// operator-supplied VolumePolicy (see WithVolumePolicy), evaluated per
+// spec.volumes[i]. When no VolumePolicy is configured, it falls back to
+// exactly CheckRestrictedVolumes' behavior.
+func CheckRestrictedVolumesPolicy() Check {
+	return Check{
+		ID:    "restrictedVolumes_policy",
+		Level: api.LevelRestricted,
+		Versions: []VersionedCheck{
+			{
+				MinimumVersion:   api.MajorMinorVersion(1, 0),
+				CheckPod:         withOptions(restrictedVolumesPolicy_1_0),
+				OverrideCheckIDs: []CheckID{"restrictedVolumes", checkHostPathVolumesID},
+			},
+		},
+	}
+}
+
+func restrictedVolumesPolicy_1_0(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, opts options) CheckResult {
+	if opts.volumePolicy == nil {
+		// No VolumePolicy configured: defer entirely to the fixed
+		// allow-list this check exists to extend.
+		return restrictedVolumes_1_0(podMetadata, podSpec, opts)
+	}
+
+	podLabels := labels.Set(podMetadata.Labels)
+	namespaceLabels := opts.namespaceLabels
+
+	var badVolumes []string
+	var errList field.ErrorList
+	deniedVolumeTypes := sets.NewString()
+
+	for i, volume := range podSpec.Volumes {
+		volumeType := volumeTypeName(volume)
+		storageClass, hasStorageClass := opts.volumeStorageClass(podMetadata.Namespace, volume)
+		action, matched := opts.volumePolicy.action(volumeType, storageClass, hasStorageClass, podLabels, namespaceLabels)
+
+		if matched {
+			if action == VolumePolicyActionAllow {
+				continue
+			}
+			// action == VolumePolicyActionDeny: falls through to badVolumes
+			// below even for a restrictedVolumesSafeTypes member, so a rule
+			// can deny a type the restricted profile would otherwise allow
+			// by default.
+		} else if restrictedVolumesSafeTypes.Has(volumeType) {
+			continue
+		}
+
+		badVolumes = append(badVolumes, volume.Name)
+		deniedVolumeTypes.Insert(volumeType)
+		index, typeName := i, volumeType
+		opts.errListHandler(func() {
+			path := volumesPath.index(index)
+			err := withBadValue(field.Forbidden(path(), ""), []string{
+				path.child(typeName)().String(),
+			})
+			errList = append(errList, err)
+		})
+	}
+
+	if len(badVolumes) > 0 {
+		return CheckResult{
+			Allowed:         false,
+			ForbiddenReason: "restricted volume types",
+			ForbiddenDetail: fmt.Sprintf(
+				"%s %s %s %s %s",
+				pluralize("volume", "volumes", len(badVolumes)),
+				joinQuote(badVolumes),
+				pluralize("uses", "use", len(badVolumes)),
+				pluralize("restricted volume type", "restricted volume types", len(deniedVolumeTypes)),
+				joinQuote(deniedVolumeTypes.List()),
+			),
+			ErrList: errList,
+		}
+	}
+
+	return CheckResult{Allowed: true}
+}
+
+// volumeTypeName returns the same type name restrictedVolumes_1_0 would
+// report in its ForbiddenDetail/badVolumeTypes for volume, by deferring to
+// the same VolumeTypeChecker registry, so a checker registered or
+// overridden via RegisterVolumeTypeChecker is classified identically by
+// both checks.
+func volumeTypeName(volume corev1.Volume) string {
+	if checker := matchVolumeTypeChecker(&volume); checker != nil {
+		return checker.Name()
+	}
+	return "unknown"
+}
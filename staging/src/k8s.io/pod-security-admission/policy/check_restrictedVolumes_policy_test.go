@@ -0,0 +1,140 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRestrictedVolumesPolicyFallback(t *testing.T) {
+	pod := &corev1.Pod{Spec: corev1.PodSpec{Volumes: []corev1.Volume{
+		{Name: "a", VolumeSource: corev1.VolumeSource{NFS: &corev1.NFSVolumeSource{}}},
+	}}}
+
+	// No VolumePolicy configured: behaves exactly like CheckRestrictedVolumes.
+	result := restrictedVolumesPolicy_1_0(&pod.ObjectMeta, &pod.Spec, options{})
+	if result.Allowed {
+		t.Errorf("expected Allowed=false without a configured VolumePolicy, got true")
+	}
+}
+
+func TestRestrictedVolumesPolicyAllowsConfiguredException(t *testing.T) {
+	compiled, err := CompileVolumePolicy(&VolumePolicy{
+		Version: "v1",
+		VolumePolicies: []VolumePolicyRule{
+			{
+				Conditions: VolumePolicyConditions{VolumeTypes: []string{"nfs"}},
+				Action:     VolumePolicyActionAllow,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CompileVolumePolicy: %v", err)
+	}
+
+	pod := &corev1.Pod{Spec: corev1.PodSpec{Volumes: []corev1.Volume{
+		{Name: "a", VolumeSource: corev1.VolumeSource{NFS: &corev1.NFSVolumeSource{}}},
+		{Name: "b", VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{}}},
+	}}}
+
+	opts := options{volumePolicy: compiled}
+	result := restrictedVolumesPolicy_1_0(&pod.ObjectMeta, &pod.Spec, opts)
+	if result.Allowed {
+		t.Errorf("expected Allowed=false: hostPath isn't covered by the policy, got true")
+	}
+}
+
+func TestRestrictedVolumesPolicyNamespaceSelector(t *testing.T) {
+	compiled, err := CompileVolumePolicy(&VolumePolicy{
+		Version: "v1",
+		VolumePolicies: []VolumePolicyRule{
+			{
+				Conditions: VolumePolicyConditions{
+					VolumeTypes: []string{"nfs"},
+					NamespaceSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"team": "storage"},
+					},
+				},
+				Action: VolumePolicyActionAllow,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CompileVolumePolicy: %v", err)
+	}
+
+	pod := &corev1.Pod{Spec: corev1.PodSpec{Volumes: []corev1.Volume{
+		{Name: "a", VolumeSource: corev1.VolumeSource{NFS: &corev1.NFSVolumeSource{}}},
+	}}}
+
+	result := restrictedVolumesPolicy_1_0(&pod.ObjectMeta, &pod.Spec, options{volumePolicy: compiled})
+	if result.Allowed {
+		t.Errorf("expected Allowed=false without matching namespace labels, got true")
+	}
+
+	opts := options{volumePolicy: compiled, namespaceLabels: map[string]string{"team": "storage"}}
+	result = restrictedVolumesPolicy_1_0(&pod.ObjectMeta, &pod.Spec, opts)
+	if !result.Allowed {
+		t.Errorf("expected Allowed=true with matching namespace labels, got false (%s: %s)", result.ForbiddenReason, result.ForbiddenDetail)
+	}
+}
+
+func TestRestrictedVolumesPolicyDeniesSafeType(t *testing.T) {
+	compiled, err := CompileVolumePolicy(&VolumePolicy{
+		Version: "v1",
+		VolumePolicies: []VolumePolicyRule{
+			{
+				Conditions: VolumePolicyConditions{
+					VolumeTypes: []string{"configMap"},
+					NamespaceSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"team": "locked-down"},
+					},
+				},
+				Action: VolumePolicyActionDeny,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CompileVolumePolicy: %v", err)
+	}
+
+	pod := &corev1.Pod{Spec: corev1.PodSpec{Volumes: []corev1.Volume{
+		{Name: "a", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{}}},
+	}}}
+
+	opts := options{volumePolicy: compiled, namespaceLabels: map[string]string{"team": "locked-down"}}
+	result := restrictedVolumesPolicy_1_0(&pod.ObjectMeta, &pod.Spec, opts)
+	if result.Allowed {
+		t.Errorf("expected Allowed=false: configMap is denied by the matching rule despite being a safe type, got true")
+	}
+
+	// Outside the matching namespace, configMap falls back to its default
+	// allow as a restrictedVolumesSafeTypes member.
+	result = restrictedVolumesPolicy_1_0(&pod.ObjectMeta, &pod.Spec, options{volumePolicy: compiled})
+	if !result.Allowed {
+		t.Errorf("expected Allowed=true: configMap defaults to allowed when no rule matches, got false (%s: %s)", result.ForbiddenReason, result.ForbiddenDetail)
+	}
+}
+
+func TestRestrictedVolumesPolicyUnknownVersion(t *testing.T) {
+	if _, err := CompileVolumePolicy(&VolumePolicy{Version: "v2"}); err == nil {
+		t.Errorf("expected an error for an unsupported version, got nil")
+	}
+}
@@ -53,6 +53,7 @@ func CheckRunAsNonRoot() Check {
 			{
 				MinimumVersion: api.MajorMinorVersion(1, 0),
 				CheckPod:       withOptions(runAsNonRoot_1_0),
+				PatchPod:       withPatchOptions(patchRunAsNonRoot),
 			},
 		},
 	}
@@ -60,14 +61,14 @@ func CheckRunAsNonRoot() Check {
 
 func runAsNonRoot_1_0(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, opts options) CheckResult {
 	// things that explicitly set runAsNonRoot=false
-	var badSetters violations[string]
+	badSetters := violations[string]{withFieldErrors: opts.withFieldErrors}
 
 	podRunAsNonRoot := false
 	if podSpec.SecurityContext != nil && podSpec.SecurityContext.RunAsNonRoot != nil {
 		if !*podSpec.SecurityContext.RunAsNonRoot {
 			var errFn ErrFn
 			if opts.withFieldErrors {
-				errFn = forbidden(runAsNonRootPath, []string{"false"})
+				errFn = forbidden(runAsNonRootPath).withBadValue([]string{"false"})
 			}
 			badSetters.Add("pod", errFn)
 		} else {
@@ -76,9 +77,9 @@ func runAsNonRoot_1_0(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, o
 	}
 
 	// containers that explicitly set runAsNonRoot=false
-	var explicitlyBadContainers violations[string]
+	explicitlyBadContainers := violations[string]{withFieldErrors: opts.withFieldErrors}
 	// containers that didn't set runAsNonRoot and aren't caught by a pod-level runAsNonRoot=true
-	var implicitlyBadContainers violations[string]
+	implicitlyBadContainers := violations[string]{withFieldErrors: opts.withFieldErrors}
 	var explicitlyErrFns []ErrFn
 
 	visitContainers(podSpec, opts, func(container *corev1.Container, pathFn PathFn) {
@@ -86,7 +87,7 @@ func runAsNonRoot_1_0(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, o
 			// container explicitly set runAsNonRoot
 			if !*container.SecurityContext.RunAsNonRoot {
 				explicitlyBadContainers.Add(container.Name)
-				explicitlyErrFns = append(explicitlyErrFns, forbidden(pathFn.child("securityContext").child("runAsNonRoot"), []string{
+				explicitlyErrFns = append(explicitlyErrFns, forbidden(pathFn.child("securityContext").child("runAsNonRoot")).withBadValue([]string{
 					"false",
 				}))
 			}
@@ -139,3 +140,29 @@ func runAsNonRoot_1_0(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, o
 
 	return CheckResult{Allowed: true}
 }
+
+// PatchRunAsNonRoot sets spec.securityContext.runAsNonRoot=true when the pod
+// does not already set it, satisfying CheckRunAsNonRoot for every container
+// that doesn't explicitly opt out at the container level. It is the
+// PatchPod counterpart to CheckRunAsNonRoot, for use by the policy/patch
+// auto-remediation mode.
+func PatchRunAsNonRoot(podSpec *corev1.PodSpec) []PatchedField {
+	if podSpec.SecurityContext != nil && podSpec.SecurityContext.RunAsNonRoot != nil {
+		return nil
+	}
+	if podSpec.SecurityContext == nil {
+		podSpec.SecurityContext = &corev1.PodSecurityContext{}
+	}
+	t := true
+	podSpec.SecurityContext.RunAsNonRoot = &t
+	return []PatchedField{{
+		Field:  "pod securityContext.runAsNonRoot",
+		Detail: "set securityContext.runAsNonRoot=true",
+	}}
+}
+
+// patchRunAsNonRoot adapts PatchRunAsNonRoot, which takes no options, into
+// the PatchPodFn shape VersionedCheck.PatchPod expects.
+func patchRunAsNonRoot(podSpec *corev1.PodSpec, _ options) []PatchedField {
+	return PatchRunAsNonRoot(podSpec)
+}
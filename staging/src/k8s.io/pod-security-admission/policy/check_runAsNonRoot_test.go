@@ -0,0 +1,63 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestRunAsNonRootEphemeralContainers(t *testing.T) {
+	runAsRoot := false
+	badEphemeral := corev1.EphemeralContainer{
+		EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+			Name: "debug",
+			SecurityContext: &corev1.SecurityContext{
+				RunAsNonRoot: &runAsRoot,
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		opts    options
+		allowed bool
+	}{
+		{
+			name:    "ephemeral container with runAsNonRoot=false is rejected by default",
+			allowed: false,
+		},
+		{
+			name:    "ephemeral container exempted via WithIncludeEphemeral(false)",
+			opts:    options{includeEphemeral: boolPtr(false)},
+			allowed: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pod := &corev1.Pod{Spec: corev1.PodSpec{
+				EphemeralContainers: []corev1.EphemeralContainer{badEphemeral},
+			}}
+			result := runAsNonRoot_1_0(&pod.ObjectMeta, &pod.Spec, tc.opts)
+			if result.Allowed != tc.allowed {
+				t.Errorf("expected Allowed=%v, got Allowed=%v (%s: %s)", tc.allowed, result.Allowed, result.ForbiddenReason, result.ForbiddenDetail)
+			}
+		})
+	}
+}
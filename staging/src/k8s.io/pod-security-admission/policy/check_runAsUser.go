@@ -54,6 +54,7 @@ func CheckRunAsUser() Check {
 			{
 				MinimumVersion: api.MajorMinorVersion(1, 23),
 				CheckPod:       withOptions(runAsUser_1_23),
+				PatchPod:       withPatchOptions(patchRunAsUser),
 			},
 		},
 	}
@@ -61,7 +62,7 @@ func CheckRunAsUser() Check {
 
 func runAsUser_1_23(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, opts options) CheckResult {
 	// things that explicitly set runAsUser=0
-	badSetters := NewViolations[string](opts.withFieldErrors)
+	badSetters := violations[string]{withFieldErrors: opts.withFieldErrors}
 
 	if podSpec.SecurityContext != nil && podSpec.SecurityContext.RunAsUser != nil && *podSpec.SecurityContext.RunAsUser == 0 {
 		var errFn ErrFn
@@ -72,7 +73,7 @@ func runAsUser_1_23(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, opt
 	}
 
 	// containers that explicitly set runAsUser=0
-	explicitlyBadContainers := NewViolations[string](opts.withFieldErrors)
+	explicitlyBadContainers := violations[string]{withFieldErrors: opts.withFieldErrors}
 	var explicitlyErrFns []ErrFn
 
 	visitContainers(podSpec, opts, func(container *corev1.Container, pathFn PathFn) {
@@ -104,3 +105,36 @@ func runAsUser_1_23(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, opt
 
 	return CheckResult{Allowed: true}
 }
+
+// PatchRunAsUser clears any runAsUser=0 set at the pod or container level,
+// satisfying CheckRunAsUser. It is the PatchPod counterpart to
+// CheckRunAsUser, for use by the policy/patch auto-remediation mode.
+func PatchRunAsUser(podSpec *corev1.PodSpec) []PatchedField {
+	var changed []PatchedField
+
+	if podSpec.SecurityContext != nil && podSpec.SecurityContext.RunAsUser != nil && *podSpec.SecurityContext.RunAsUser == 0 {
+		podSpec.SecurityContext.RunAsUser = nil
+		changed = append(changed, PatchedField{
+			Field:  "pod securityContext.runAsUser",
+			Detail: "cleared disallowed runAsUser=0",
+		})
+	}
+
+	visitContainers(podSpec, options{}, func(container *corev1.Container, pathFn PathFn) {
+		if container.SecurityContext != nil && container.SecurityContext.RunAsUser != nil && *container.SecurityContext.RunAsUser == 0 {
+			container.SecurityContext.RunAsUser = nil
+			changed = append(changed, PatchedField{
+				Field:  fmt.Sprintf("container %q securityContext.runAsUser", container.Name),
+				Detail: "cleared disallowed runAsUser=0",
+			})
+		}
+	})
+
+	return changed
+}
+
+// patchRunAsUser adapts PatchRunAsUser, which takes no options, into the
+// PatchPodFn shape VersionedCheck.PatchPod expects.
+func patchRunAsUser(podSpec *corev1.PodSpec, _ options) []PatchedField {
+	return PatchRunAsUser(podSpec)
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/pod-security-admission/api"
+)
+
+const hardenedMinRunAsUser = 1000
+
+/*
+runAsUser must be explicitly set, at the pod level or on every container,
+to a value >= 1000: CheckRunAsUser only forbids runAsUser=0, leaving an
+undefined runAsUser (which falls back to whatever the image sets) or a
+low, potentially-system, UID allowed.
+
+**Restricted Fields:**
+spec.securityContext.runAsUser
+spec.containers[*].securityContext.runAsUser
+spec.initContainers[*].securityContext.runAsUser
+
+**Allowed Values:** >= 1000
+*/
+
+func init() {
+	addCheck(CheckRunAsUserHardened)
+}
+
+// CheckRunAsUserHardened returns a hardened level check that requires an
+// explicit runAsUser >= 1000 at the pod level or on every container in
+// 1.0+, tightening CheckRunAsUser's runAsUser != 0 floor.
+func CheckRunAsUserHardened() Check {
+	return Check{
+		ID:    "runAsUser_hardened",
+		Level: api.LevelHardened,
+		Versions: []VersionedCheck{
+			{
+				MinimumVersion:   api.MajorMinorVersion(1, 0),
+				CheckPod:         withOptions(runAsUserHardened_1_0),
+				OverrideCheckIDs: []CheckID{"runAsUser"},
+			},
+		},
+	}
+}
+
+func runAsUserHardened_1_0(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, opts options) CheckResult {
+	var podRunAsUser *int64
+	if podSpec.SecurityContext != nil {
+		podRunAsUser = podSpec.SecurityContext.RunAsUser
+	}
+
+	var badContainers []string
+	var errList field.ErrorList
+	visitContainers(podSpec, opts, func(container *corev1.Container, pathFn PathFn) {
+		runAsUser := podRunAsUser
+		if container.SecurityContext != nil && container.SecurityContext.RunAsUser != nil {
+			runAsUser = container.SecurityContext.RunAsUser
+		}
+		if runAsUser == nil || *runAsUser < hardenedMinRunAsUser {
+			badContainers = append(badContainers, container.Name)
+			opts.errListHandler(func() {
+				path := pathFn.child("securityContext").child("runAsUser").resolve()
+				if path == nil {
+					return
+				}
+				var badValue interface{}
+				if runAsUser != nil {
+					badValue = *runAsUser
+				}
+				errList = append(errList, withBadValue(field.Forbidden(path, ""), badValue))
+			})
+		}
+	})
+
+	if len(badContainers) > 0 {
+		return CheckResult{
+			Allowed:         false,
+			ForbiddenReason: "runAsUser",
+			ForbiddenDetail: fmt.Sprintf(
+				"%s %s must set securityContext.runAsUser >= %d, at the pod level or per container",
+				pluralize("container", "containers", len(badContainers)),
+				joinQuote(badContainers),
+				hardenedMinRunAsUser),
+			ErrList: errList,
+		}
+	}
+
+	return CheckResult{Allowed: true}
+}
@@ -65,6 +65,7 @@ func CheckSELinuxOptions() Check {
 			{
 				MinimumVersion: api.MajorMinorVersion(1, 0),
 				CheckPod:       withOptions(seLinuxOptions_1_0),
+				PatchPod:       withPatchOptions(PatchSELinuxOptions),
 			},
 		},
 	}
@@ -77,7 +78,7 @@ var (
 func seLinuxOptions_1_0(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, opts options) CheckResult {
 	var (
 		// sources that set bad seLinuxOptions
-		badSetters violations[string]
+		badSetters = violations[string]{withFieldErrors: opts.withFieldErrors}
 		errFns     []ErrFn
 
 		// invalid type values set
@@ -90,41 +91,41 @@ func seLinuxOptions_1_0(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec,
 
 	validSELinuxOptions := func(selinuxOpts *corev1.SELinuxOptions, pathFn PathFn, isPodLevel bool) bool {
 		valid := true
-		if !selinux_allowed_types_1_0.Has(selinuxOpts.Type) {
+		if !selinux_allowed_types_1_0.Has(selinuxOpts.Type) && !opts.extraAllowedSELinuxTypes.Has(selinuxOpts.Type) {
 			valid = false
 			badTypes.Insert(selinuxOpts.Type)
 			if pathFn != nil {
-				errFns = append(errFns, forbidden(pathFn.child("securityContext").child("seLinuxOptions").child("type"), []string{
+				errFns = append(errFns, forbidden(pathFn.child("securityContext").child("seLinuxOptions").child("type")).withBadValue([]string{
 					selinuxOpts.Type,
 				}))
 			} else if isPodLevel && opts.withFieldErrors {
-				errFns = append(errFns, forbidden(seLinuxOptionsTypePath, []string{
+				errFns = append(errFns, forbidden(seLinuxOptionsTypePath).withBadValue([]string{
 					selinuxOpts.Type,
 				}))
 			}
 		}
-		if len(selinuxOpts.User) > 0 {
+		if len(selinuxOpts.User) > 0 && !opts.allowedSELinuxUsers.Has(selinuxOpts.User) {
 			valid = false
 			setUser = true
 			if pathFn != nil {
-				errFns = append(errFns, forbidden(pathFn.child("securityContext").child("seLinuxOptions").child("user"), []string{
+				errFns = append(errFns, forbidden(pathFn.child("securityContext").child("seLinuxOptions").child("user")).withBadValue([]string{
 					selinuxOpts.User,
 				}))
 			} else if isPodLevel && opts.withFieldErrors {
-				errFns = append(errFns, forbidden(seLinuxOptionsUserPath, []string{
+				errFns = append(errFns, forbidden(seLinuxOptionsUserPath).withBadValue([]string{
 					selinuxOpts.User,
 				}))
 			}
 		}
-		if len(selinuxOpts.Role) > 0 {
+		if len(selinuxOpts.Role) > 0 && !opts.allowedSELinuxRoles.Has(selinuxOpts.Role) {
 			valid = false
 			setRole = true
 			if pathFn != nil {
-				errFns = append(errFns, forbidden(pathFn.child("securityContext").child("seLinuxOptions").child("role"), []string{
+				errFns = append(errFns, forbidden(pathFn.child("securityContext").child("seLinuxOptions").child("role")).withBadValue([]string{
 					selinuxOpts.Role,
 				}))
 			} else if isPodLevel && opts.withFieldErrors {
-				errFns = append(errFns, forbidden(seLinuxOptionsRolePath, []string{
+				errFns = append(errFns, forbidden(seLinuxOptionsRolePath).withBadValue([]string{
 					selinuxOpts.Role,
 				}))
 			}
@@ -138,8 +139,11 @@ func seLinuxOptions_1_0(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec,
 		}
 	}
 
-	var badContainers violations[string]
+	badContainers := violations[string]{withFieldErrors: opts.withFieldErrors}
 	visitContainers(podSpec, opts, func(container *corev1.Container, pathFn PathFn) {
+		if opts.imageExempt(container.Image) {
+			return
+		}
 		if container.SecurityContext != nil && container.SecurityContext.SELinuxOptions != nil {
 			if !validSELinuxOptions(container.SecurityContext.SELinuxOptions, pathFn, false) {
 				badContainers.Add(container.Name)
@@ -187,3 +191,51 @@ func seLinuxOptions_1_0(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec,
 	}
 	return CheckResult{Allowed: true}
 }
+
+// PatchSELinuxOptions clears seLinuxOptions fields that CheckSELinuxOptions
+// forbids: User and Role are cleared unless allowed by
+// opts.allowedSELinuxUsers/opts.allowedSELinuxRoles, and Type is reset to ""
+// when it is not in the allow-list (including opts.extraAllowedSELinuxTypes).
+// It is the PatchPod counterpart to CheckSELinuxOptions, for use by the
+// policy/patch auto-remediation mode.
+func PatchSELinuxOptions(podSpec *corev1.PodSpec, opts options) []PatchedField {
+	var changed []PatchedField
+
+	patch := func(who string, o *corev1.SELinuxOptions) {
+		if o == nil {
+			return
+		}
+		if !selinux_allowed_types_1_0.Has(o.Type) && !opts.extraAllowedSELinuxTypes.Has(o.Type) {
+			o.Type = ""
+			changed = append(changed, PatchedField{
+				Field:  fmt.Sprintf("%s securityContext.seLinuxOptions.type", who),
+				Detail: "cleared disallowed seLinuxOptions value",
+			})
+		}
+		if len(o.User) > 0 && !opts.allowedSELinuxUsers.Has(o.User) {
+			o.User = ""
+			changed = append(changed, PatchedField{
+				Field:  fmt.Sprintf("%s securityContext.seLinuxOptions.user", who),
+				Detail: "cleared disallowed seLinuxOptions value",
+			})
+		}
+		if len(o.Role) > 0 && !opts.allowedSELinuxRoles.Has(o.Role) {
+			o.Role = ""
+			changed = append(changed, PatchedField{
+				Field:  fmt.Sprintf("%s securityContext.seLinuxOptions.role", who),
+				Detail: "cleared disallowed seLinuxOptions value",
+			})
+		}
+	}
+
+	if podSpec.SecurityContext != nil {
+		patch("pod", podSpec.SecurityContext.SELinuxOptions)
+	}
+	visitContainers(podSpec, opts, func(container *corev1.Container, pathFn PathFn) {
+		if container.SecurityContext != nil {
+			patch(fmt.Sprintf("container %q", container.Name), container.SecurityContext.SELinuxOptions)
+		}
+	})
+
+	return changed
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestSELinuxOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		pod     *corev1.Pod
+		opts    options
+		allowed bool
+	}{
+		{
+			name: "pod-level bad type",
+			pod: &corev1.Pod{Spec: corev1.PodSpec{
+				SecurityContext: &corev1.PodSecurityContext{
+					SELinuxOptions: &corev1.SELinuxOptions{Type: "spc_t"},
+				},
+			}},
+			allowed: false,
+		},
+		{
+			name: "pod-level type allowed via extension",
+			pod: &corev1.Pod{Spec: corev1.PodSpec{
+				SecurityContext: &corev1.PodSecurityContext{
+					SELinuxOptions: &corev1.SELinuxOptions{Type: "spc_t"},
+				},
+			}},
+			opts:    options{extraAllowedSELinuxTypes: sets.NewString("spc_t")},
+			allowed: true,
+		},
+		{
+			name: "container-level type allowed via extension",
+			pod: &corev1.Pod{Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name: "a",
+						SecurityContext: &corev1.SecurityContext{
+							SELinuxOptions: &corev1.SELinuxOptions{Type: "spc_t"},
+						},
+					},
+				},
+			}},
+			opts:    options{extraAllowedSELinuxTypes: sets.NewString("spc_t")},
+			allowed: true,
+		},
+		{
+			name: "container-level bad type not exempted",
+			pod: &corev1.Pod{Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name: "a",
+						SecurityContext: &corev1.SecurityContext{
+							SELinuxOptions: &corev1.SELinuxOptions{Type: "spc_t"},
+						},
+					},
+				},
+			}},
+			allowed: false,
+		},
+		{
+			name: "container-level bad type exempted by image",
+			pod: &corev1.Pod{Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name:  "a",
+						Image: "registry.example.com/trusted/debug:v1",
+						SecurityContext: &corev1.SecurityContext{
+							SELinuxOptions: &corev1.SELinuxOptions{Type: "spc_t"},
+						},
+					},
+				},
+			}},
+			opts:    options{exemptImages: []string{"registry.example.com/trusted/*"}},
+			allowed: true,
+		},
+		{
+			name: "pod-level user allowed via extension",
+			pod: &corev1.Pod{Spec: corev1.PodSpec{
+				SecurityContext: &corev1.PodSecurityContext{
+					SELinuxOptions: &corev1.SELinuxOptions{User: "user_u"},
+				},
+			}},
+			opts:    options{allowedSELinuxUsers: sets.NewString("user_u")},
+			allowed: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result := seLinuxOptions_1_0(&tc.pod.ObjectMeta, &tc.pod.Spec, tc.opts)
+			if result.Allowed != tc.allowed {
+				t.Errorf("expected Allowed=%v, got Allowed=%v (%s: %s)", tc.allowed, result.Allowed, result.ForbiddenReason, result.ForbiddenDetail)
+			}
+		})
+	}
+}
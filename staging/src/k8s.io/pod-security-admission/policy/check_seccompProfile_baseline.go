@@ -68,6 +68,7 @@ func CheckSeccompBaseline() Check {
 			{
 				MinimumVersion: api.MajorMinorVersion(1, 19),
 				CheckPod:       withOptions(seccompProfileBaseline_1_19),
+				PatchPod:       withPatchOptions(PatchSeccompBaseline),
 			},
 		},
 	}
@@ -78,6 +79,23 @@ func validSeccomp(t corev1.SeccompProfileType) bool {
 		t == corev1.SeccompProfileTypeRuntimeDefault
 }
 
+// validSeccompProfile is like validSeccomp, but additionally consults
+// opts.allowedLocalhostSeccompProfiles for profiles of type Localhost: if
+// that allowlist is non-empty, only the named localhostProfile values are
+// permitted, instead of every localhost profile.
+func validSeccompProfile(profile *corev1.SeccompProfile, opts options) bool {
+	if !validSeccomp(profile.Type) {
+		return false
+	}
+	if profile.Type == corev1.SeccompProfileTypeLocalhost && len(opts.allowedLocalhostSeccompProfiles) > 0 {
+		if profile.LocalhostProfile == nil {
+			return false
+		}
+		return opts.allowedLocalhostSeccompProfiles.Has(*profile.LocalhostProfile)
+	}
+	return true
+}
+
 func validSeccompAnnotationValue(v string) bool {
 	return v == corev1.SeccompProfileRuntimeDefault ||
 		v == corev1.DeprecatedSeccompProfileDockerDefault ||
@@ -100,7 +118,7 @@ func seccompProfileBaseline_1_0(podMetadata *metav1.ObjectMeta, podSpec *corev1.
 		}
 	}
 
-	visitContainersWithPath(podSpec, func(c *corev1.Container, path *field.Path) {
+	visitContainers(podSpec, opts, func(c *corev1.Container, pathFn PathFn) {
 		annotation := annotationKeyContainerPrefix + c.Name
 		if val, ok := podMetadata.Annotations[annotation]; ok {
 			if !validSeccompAnnotationValue(val) {
@@ -138,7 +156,7 @@ func seccompProfileBaseline_1_19(podMetadata *metav1.ObjectMeta, podSpec *corev1
 	var errList field.ErrorList
 
 	if podSpec.SecurityContext != nil && podSpec.SecurityContext.SeccompProfile != nil {
-		if !validSeccomp(podSpec.SecurityContext.SeccompProfile.Type) {
+		if !validSeccompProfile(podSpec.SecurityContext.SeccompProfile, opts) {
 			badSetters = append(badSetters, "pod")
 			badValues.Insert(string(podSpec.SecurityContext.SeccompProfile.Type))
 			opts.errListHandler(func() {
@@ -153,16 +171,20 @@ func seccompProfileBaseline_1_19(podMetadata *metav1.ObjectMeta, podSpec *corev1
 	// containers that explicitly set seccompProfile.type to a bad value
 	var explicitlyBadContainers []string
 
-	visitContainersWithPath(podSpec, func(c *corev1.Container, path *field.Path) {
+	visitContainers(podSpec, opts, func(c *corev1.Container, pathFn PathFn) {
 		if c.SecurityContext != nil && c.SecurityContext.SeccompProfile != nil {
 			// container explicitly set seccompProfile
-			if !validSeccomp(c.SecurityContext.SeccompProfile.Type) {
+			if !validSeccompProfile(c.SecurityContext.SeccompProfile, opts) {
 				// container explicitly set seccompProfile to a bad value
 				explicitlyBadContainers = append(explicitlyBadContainers, c.Name)
 				badValues.Insert(string(c.SecurityContext.SeccompProfile.Type))
 				opts.errListHandler(func() {
-					err := withBadValue(field.Forbidden(path.Child("securityContext").Child("seccompProfile").Child("type"), ""), []string{
-						string(podSpec.SecurityContext.SeccompProfile.Type),
+					path := pathFn.child("securityContext").child("seccompProfile").child("type").resolve()
+					if path == nil {
+						return
+					}
+					err := withBadValue(field.Forbidden(path, ""), []string{
+						string(c.SecurityContext.SeccompProfile.Type),
 					})
 					errList = append(errList, err)
 				})
@@ -182,17 +204,55 @@ func seccompProfileBaseline_1_19(podMetadata *metav1.ObjectMeta, podSpec *corev1
 	}
 	// pod or containers explicitly set bad seccompProfiles
 	if len(badSetters) > 0 {
+		detail := fmt.Sprintf(
+			"%s must not set securityContext.seccompProfile.type to %s",
+			strings.Join(badSetters, " and "),
+			joinQuote(badValues.List()),
+		)
+		if len(opts.allowedLocalhostSeccompProfiles) > 0 {
+			detail += ", or localhostProfile outside the configured allowed profiles"
+		}
 		return CheckResult{
 			Allowed:         false,
 			ForbiddenReason: "seccompProfile",
-			ForbiddenDetail: fmt.Sprintf(
-				"%s must not set securityContext.seccompProfile.type to %s",
-				strings.Join(badSetters, " and "),
-				joinQuote(badValues.List()),
-			),
-			ErrList: errList,
+			ForbiddenDetail: detail,
+			ErrList:         errList,
 		}
 	}
 
 	return CheckResult{Allowed: true}
 }
+
+// PatchSeccompBaseline sets the pod-level securityContext.seccompProfile.type
+// to RuntimeDefault when it is unset or set to a value CheckSeccompBaseline
+// forbids (including a Localhost profile outside
+// opts.allowedLocalhostSeccompProfiles), and does the same for a container
+// whose own seccompProfile is set to a forbidden value. A container with no
+// seccompProfile of its own is left unset and inherits the pod level. It is the PatchPod counterpart to CheckSeccompBaseline, for use by
+// the policy/patch auto-remediation mode.
+func PatchSeccompBaseline(podSpec *corev1.PodSpec, opts options) []PatchedField {
+	var changed []PatchedField
+
+	if podSpec.SecurityContext == nil {
+		podSpec.SecurityContext = &corev1.PodSecurityContext{}
+	}
+	if podSpec.SecurityContext.SeccompProfile == nil || !validSeccompProfile(podSpec.SecurityContext.SeccompProfile, opts) {
+		podSpec.SecurityContext.SeccompProfile = &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault}
+		changed = append(changed, PatchedField{
+			Field:  "pod securityContext.seccompProfile.type",
+			Detail: "set seccompProfile.type to RuntimeDefault",
+		})
+	}
+
+	visitContainers(podSpec, opts, func(c *corev1.Container, pathFn PathFn) {
+		if c.SecurityContext != nil && c.SecurityContext.SeccompProfile != nil && !validSeccompProfile(c.SecurityContext.SeccompProfile, opts) {
+			c.SecurityContext.SeccompProfile = &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault}
+			changed = append(changed, PatchedField{
+				Field:  fmt.Sprintf("container %q securityContext.seccompProfile.type", c.Name),
+				Detail: "set seccompProfile.type to RuntimeDefault",
+			})
+		}
+	})
+
+	return changed
+}
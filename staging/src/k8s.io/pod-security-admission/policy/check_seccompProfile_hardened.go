@@ -0,0 +1,125 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/pod-security-admission/api"
+)
+
+/*
+seccompProfile must be explicitly set, at the pod level or on every
+container, and the value must be RuntimeDefault or an allowed Localhost
+profile: CheckSeccompBaseline only rejects an explicit bad value, leaving
+undefined (which lets the container runtime's own default apply) as
+allowed. Hardened removes that gap.
+
+**Restricted Fields:**
+spec.securityContext.seccompProfile
+spec.containers[*].securityContext.seccompProfile
+spec.initContainers[*].securityContext.seccompProfile
+
+**Allowed Values:** 'RuntimeDefault', 'Localhost' (subject to
+opts.allowedLocalhostSeccompProfiles)
+*/
+
+func init() {
+	addCheck(CheckSeccompHardened)
+}
+
+// CheckSeccompHardened returns a hardened level check that requires an
+// explicit, valid seccompProfile at the pod level or on every container in
+// 1.0+, tightening CheckSeccompBaseline's "undefined is allowed" default.
+func CheckSeccompHardened() Check {
+	return Check{
+		ID:    "seccompProfile_hardened",
+		Level: api.LevelHardened,
+		Versions: []VersionedCheck{
+			{
+				MinimumVersion:   api.MajorMinorVersion(1, 0),
+				CheckPod:         withOptions(seccompHardened_1_0),
+				OverrideCheckIDs: []CheckID{checkSeccompBaselineID},
+			},
+		},
+	}
+}
+
+func seccompHardened_1_0(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, opts options) CheckResult {
+	podSeccomp := podSpec.SecurityContext != nil && podSpec.SecurityContext.SeccompProfile != nil
+	podValid := podSeccomp && validSeccompProfile(podSpec.SecurityContext.SeccompProfile, opts)
+
+	if podSeccomp && !podValid {
+		var errList field.ErrorList
+		opts.errListHandler(func() {
+			errList = append(errList, withBadValue(field.Forbidden(seccompProfileTypePath, ""), podSpec.SecurityContext.SeccompProfile.Type))
+		})
+		return CheckResult{
+			Allowed:         false,
+			ForbiddenReason: "seccompProfile",
+			ForbiddenDetail: fmt.Sprintf("pod must not set securityContext.seccompProfile.type to %q", podSpec.SecurityContext.SeccompProfile.Type),
+			ErrList:         errList,
+		}
+	}
+
+	var missingOrBadContainers []string
+	var errList field.ErrorList
+	visitContainers(podSpec, opts, func(container *corev1.Container, pathFn PathFn) {
+		if container.SecurityContext != nil && container.SecurityContext.SeccompProfile != nil {
+			if !validSeccompProfile(container.SecurityContext.SeccompProfile, opts) {
+				missingOrBadContainers = append(missingOrBadContainers, container.Name)
+				opts.errListHandler(func() {
+					path := pathFn.child("securityContext").child("seccompProfile").child("type").resolve()
+					if path == nil {
+						return
+					}
+					errList = append(errList, withBadValue(field.Forbidden(path, ""), container.SecurityContext.SeccompProfile.Type))
+				})
+			}
+			return
+		}
+		// container didn't set its own seccompProfile; only acceptable if
+		// the pod level already set a valid one.
+		if !podValid {
+			missingOrBadContainers = append(missingOrBadContainers, container.Name)
+			opts.errListHandler(func() {
+				path := pathFn.child("securityContext").child("seccompProfile").resolve()
+				if path == nil {
+					return
+				}
+				errList = append(errList, field.Required(path, ""))
+			})
+		}
+	})
+
+	if len(missingOrBadContainers) > 0 {
+		return CheckResult{
+			Allowed:         false,
+			ForbiddenReason: "seccompProfile",
+			ForbiddenDetail: fmt.Sprintf(
+				"pod or %s %s must set a valid securityContext.seccompProfile",
+				pluralize("container", "containers", len(missingOrBadContainers)),
+				joinQuote(missingOrBadContainers)),
+			ErrList: errList,
+		}
+	}
+
+	return CheckResult{Allowed: true}
+}
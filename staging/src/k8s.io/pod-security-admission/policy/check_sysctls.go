@@ -17,6 +17,7 @@ limitations under the License.
 package policy
 
 import (
+	"fmt"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
@@ -60,24 +61,26 @@ func CheckSysctls() Check {
 			{
 				MinimumVersion: api.MajorMinorVersion(1, 0),
 				CheckPod:       withOptions(sysctls_1_0),
+				PatchPod:       patchSysctlsForVersionedCheck(api.LevelVersion{Level: api.LevelBaseline, Version: api.MajorMinorVersion(1, 0)}),
 			},
 			{
 				MinimumVersion: api.MajorMinorVersion(1, 27),
 				CheckPod:       withOptions(sysctls_1_27),
+				PatchPod:       patchSysctlsForVersionedCheck(api.LevelVersion{Level: api.LevelBaseline, Version: api.MajorMinorVersion(1, 27)}),
 			},
 		},
 	}
 }
 
 var (
-	sysctls_allowed_1_0 = sets.NewString(
+	sysctls_allowed_1_0 = newSysctlMatcherOrDie(
 		"kernel.shm_rmid_forced",
 		"net.ipv4.ip_local_port_range",
 		"net.ipv4.tcp_syncookies",
 		"net.ipv4.ping_group_range",
 		"net.ipv4.ip_unprivileged_port_start",
 	)
-	sysctls_allowed_1_27 = sets.NewString(
+	sysctls_allowed_1_27 = newSysctlMatcherOrDie(
 		"kernel.shm_rmid_forced",
 		"net.ipv4.ip_local_port_range",
 		"net.ipv4.tcp_syncookies",
@@ -87,6 +90,70 @@ var (
 	)
 )
 
+// sysctlMatcher matches sysctl names against an allow-list that may contain
+// exact names as well as wildcard patterns with a single trailing "*",
+// mirroring the convention kubelet uses for --allowed-unsafe-sysctls.
+type sysctlMatcher struct {
+	exact    sets.String
+	prefixes []string
+}
+
+// newSysctlMatcher validates and compiles patterns into a sysctlMatcher.
+// Empty patterns, and patterns with an internal (non-trailing) "*", are
+// rejected.
+func newSysctlMatcher(patterns []string) (sysctlMatcher, error) {
+	m := sysctlMatcher{exact: sets.NewString()}
+	for _, pattern := range patterns {
+		if len(pattern) == 0 {
+			return sysctlMatcher{}, fmt.Errorf("empty sysctl pattern")
+		}
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.Contains(prefix, "*") {
+				return sysctlMatcher{}, fmt.Errorf("pattern %q: only a single trailing * is allowed", pattern)
+			}
+			m.prefixes = append(m.prefixes, prefix)
+			continue
+		}
+		if strings.Contains(pattern, "*") {
+			return sysctlMatcher{}, fmt.Errorf("pattern %q: only a single trailing * is allowed", pattern)
+		}
+		m.exact.Insert(pattern)
+	}
+	return m, nil
+}
+
+// newSysctlMatcherOrDie is used to build the package's pinned, known-good
+// allow-lists; it panics on invalid patterns rather than threading an error
+// through package-level var initialization.
+func newSysctlMatcherOrDie(patterns ...string) sysctlMatcher {
+	m, err := newSysctlMatcher(patterns)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// Has reports whether name is an exact match or matches a wildcard prefix.
+func (m sysctlMatcher) Has(name string) bool {
+	if m.exact.Has(name) {
+		return true
+	}
+	for _, prefix := range m.prefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// union returns a sysctlMatcher that matches anything m or other matches.
+func (m sysctlMatcher) union(other sysctlMatcher) sysctlMatcher {
+	return sysctlMatcher{
+		exact:    m.exact.Union(other.exact),
+		prefixes: append(append([]string{}, m.prefixes...), other.prefixes...),
+	}
+}
+
 func sysctls_1_0(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, opts options) CheckResult {
 	return sysctls(podMetadata, podSpec, sysctls_allowed_1_0, opts)
 }
@@ -95,14 +162,84 @@ func sysctls_1_27(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, opts
 	return sysctls(podMetadata, podSpec, sysctls_allowed_1_27, opts)
 }
 
-func sysctls(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, sysctls_allowed_set sets.String, opts options) CheckResult {
+// CheckSysctlsWithExtraAllowed returns a baseline level check like
+// CheckSysctls, except that patterns is additionally unioned into both the
+// 1.0+ and the 1.27+ allow-lists, and its Versions carry no PatchPod. This lets a cluster admin align the admission policy with the
+// set of sysctls their kubelets accept via --allowed-unsafe-sysctls,
+// avoiding pods that are admitted only to fail at kubelet start. Patterns
+// are validated immediately; an invalid pattern panics, since this is
+// intended to be called with static configuration at process startup.
+func CheckSysctlsWithExtraAllowed(patterns []string) Check {
+	extra := newSysctlMatcherOrDie(patterns...)
+	allowed1_0 := sysctls_allowed_1_0.union(extra)
+	allowed1_27 := sysctls_allowed_1_27.union(extra)
+	return Check{
+		ID:    "sysctls",
+		Level: api.LevelBaseline,
+		Versions: []VersionedCheck{
+			{
+				MinimumVersion: api.MajorMinorVersion(1, 0),
+				CheckPod: withOptions(func(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, opts options) CheckResult {
+					return sysctls(podMetadata, podSpec, allowed1_0, opts)
+				}),
+			},
+			{
+				MinimumVersion: api.MajorMinorVersion(1, 27),
+				CheckPod: withOptions(func(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, opts options) CheckResult {
+					return sysctls(podMetadata, podSpec, allowed1_27, opts)
+				}),
+			},
+		},
+	}
+}
+
+// ContainerSysctlExtractor returns the names of any sysctls that apply to a
+// single container, for container-level or annotation-sourced sysctl
+// mechanisms that bypass spec.securityContext.sysctls (e.g. a future
+// per-container CRI field, or a vendor annotation). The default is a no-op
+// that reports no sysctls.
+type ContainerSysctlExtractor func(container *corev1.Container) []string
+
+// legacyUnsafeSysctlsAnnotationKey is the pre-1.0 alpha annotation some
+// clusters still carry forward, superseded by spec.securityContext.sysctls
+// but still worth inspecting defensively so it can't be used to bypass this
+// check. Only this key is read; the sibling
+// security.alpha.kubernetes.io/sysctls annotation is not inspected.
+const legacyUnsafeSysctlsAnnotationKey = "security.alpha.kubernetes.io/unsafe-sysctls"
+
+var legacyUnsafeSysctlsAnnotationPath = annotationsPath.key(legacyUnsafeSysctlsAnnotationKey)
+
+// legacyAnnotationSysctls extracts sysctl names from the legacy alpha
+// sysctls annotation, which stores a comma-separated list of name=value
+// pairs.
+func legacyAnnotationSysctls(podMetadata *metav1.ObjectMeta) []string {
+	val, ok := podMetadata.Annotations[legacyUnsafeSysctlsAnnotationKey]
+	if !ok || len(val) == 0 {
+		return nil
+	}
+	var names []string
+	for _, pair := range strings.Split(val, ",") {
+		name := pair
+		if idx := strings.Index(pair, "="); idx >= 0 {
+			name = pair[:idx]
+		}
+		names = append(names, strings.TrimSpace(name))
+	}
+	return names
+}
+
+func sysctls(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, sysctls_allowed_set sysctlMatcher, opts options) CheckResult {
 	forbiddenSysctls := violations[string]{
 		withFieldErrors: opts.withFieldErrors,
 	}
 
+	allowed := func(name string) bool {
+		return sysctls_allowed_set.Has(name) || opts.extraAllowedSysctls.Has(name)
+	}
+
 	if podSpec.SecurityContext != nil {
 		for i, sysctl := range podSpec.SecurityContext.Sysctls {
-			if !sysctls_allowed_set.Has(sysctl.Name) {
+			if !allowed(sysctl.Name) {
 				var errFn ErrFn
 				if opts.withFieldErrors {
 					errFn = forbidden(sysctlsPath.index(i).child("name")).withBadValue([]string{
@@ -114,6 +251,37 @@ func sysctls(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, sysctls_al
 		}
 	}
 
+	for _, name := range legacyAnnotationSysctls(podMetadata) {
+		if !allowed(name) {
+			var errFn ErrFn
+			if opts.withFieldErrors {
+				errFn = forbidden(legacyUnsafeSysctlsAnnotationPath).withBadValue([]string{
+					name,
+				})
+			}
+			forbiddenSysctls.Add(name, errFn)
+		}
+	}
+
+	if opts.containerSysctlExtractor != nil {
+		visitContainers(podSpec, opts, func(container *corev1.Container, pathFn PathFn) {
+			if opts.imageExempt(container.Image) {
+				return
+			}
+			for _, name := range opts.containerSysctlExtractor(container) {
+				if !allowed(name) {
+					var errFn ErrFn
+					if opts.withFieldErrors && pathFn != nil {
+						errFn = forbidden(pathFn.child("securityContext").child("sysctls")).withBadValue([]string{
+							name,
+						})
+					}
+					forbiddenSysctls.Add(name, errFn)
+				}
+			}
+		})
+	}
+
 	if !forbiddenSysctls.Empty() {
 		return CheckResult{
 			Allowed:         false,
@@ -124,3 +292,45 @@ func sysctls(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, sysctls_al
 	}
 	return CheckResult{Allowed: true}
 }
+
+// PatchSysctls removes any spec.securityContext.sysctls entries that are not
+// in the allow-list for lv.Version, returning a PatchedField per removed
+// entry. It is the PatchPod counterpart to CheckSysctls: where CheckPod
+// rejects a pod with disallowed sysctls, PatchSysctls mutates the pod into
+// conformance instead, for use by the policy/patch auto-remediation mode.
+func PatchSysctls(podSpec *corev1.PodSpec, lv api.LevelVersion) []PatchedField {
+	if podSpec.SecurityContext == nil {
+		return nil
+	}
+
+	allowed := sysctls_allowed_1_0
+	if !lv.Version.Older(api.MajorMinorVersion(1, 27)) {
+		allowed = sysctls_allowed_1_27
+	}
+
+	var removed []PatchedField
+	kept := podSpec.SecurityContext.Sysctls[:0]
+	for _, sysctl := range podSpec.SecurityContext.Sysctls {
+		if allowed.Has(sysctl.Name) {
+			kept = append(kept, sysctl)
+		} else {
+			removed = append(removed, PatchedField{
+				Field:  "spec.securityContext.sysctls",
+				Detail: fmt.Sprintf("removed disallowed sysctl %q", sysctl.Name),
+			})
+		}
+	}
+	podSpec.SecurityContext.Sysctls = kept
+	return removed
+}
+
+// patchSysctlsForVersionedCheck adapts PatchSysctls, which needs the target
+// lv.Version to pick an allow-list, into the opts-only PatchPodFn shape a
+// VersionedCheck.PatchPod exposes: each VersionedCheck entry below already
+// pins the version its allow-list applies from, so the closure just
+// supplies that fixed lv rather than threading the caller's lv through.
+func patchSysctlsForVersionedCheck(lv api.LevelVersion) PatchPodFn {
+	return withPatchOptions(func(podSpec *corev1.PodSpec, opts options) []PatchedField {
+		return PatchSysctls(podSpec, lv)
+	})
+}
@@ -19,6 +19,8 @@ package policy
 import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"testing"
 
@@ -180,6 +182,19 @@ func TestSysctls_1_27(t *testing.T) {
 			},
 			allowed: true,
 		},
+		{
+			name: "extra allowed sysctl",
+			pod: &corev1.Pod{Spec: corev1.PodSpec{
+				SecurityContext: &corev1.PodSecurityContext{
+					Sysctls: []corev1.Sysctl{{Name: "net.ipv4.tcp_keepalive_time"}},
+				},
+			}},
+			opts: options{
+				withFieldErrors:     false,
+				extraAllowedSysctls: sets.NewString("net.ipv4.tcp_keepalive_time"),
+			},
+			allowed: true,
+		},
 		{
 			name: "new supported sysctls, enable field error list",
 			pod: &corev1.Pod{Spec: corev1.PodSpec{
@@ -219,3 +234,132 @@ func TestSysctls_1_27(t *testing.T) {
 		})
 	}
 }
+
+func TestSysctlMatcher(t *testing.T) {
+	tests := []struct {
+		name      string
+		patterns  []string
+		expectErr bool
+		matches   []string
+		noMatches []string
+	}{
+		{
+			name:     "exact names",
+			patterns: []string{"net.ipv4.ip_local_port_range"},
+			matches:  []string{"net.ipv4.ip_local_port_range"},
+			noMatches: []string{
+				"net.ipv4.ip_local_port_ranges",
+				"net.ipv4",
+			},
+		},
+		{
+			name:      "prefix match",
+			patterns:  []string{"net.ipv4.tcp_*"},
+			matches:   []string{"net.ipv4.tcp_syncookies", "net.ipv4.tcp_keepalive_time"},
+			noMatches: []string{"net.ipv4.ip_local_port_range", "net.ipv6.tcp_foo"},
+		},
+		{
+			name:      "empty pattern rejected",
+			patterns:  []string{""},
+			expectErr: true,
+		},
+		{
+			name:      "internal wildcard rejected",
+			patterns:  []string{"net.*.tcp_syncookies"},
+			expectErr: true,
+		},
+		{
+			name:      "multiple trailing wildcards rejected",
+			patterns:  []string{"net.ipv4.**"},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m, err := newSysctlMatcher(tc.patterns)
+			if tc.expectErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, name := range tc.matches {
+				if !m.Has(name) {
+					t.Errorf("expected %q to match", name)
+				}
+			}
+			for _, name := range tc.noMatches {
+				if m.Has(name) {
+					t.Errorf("expected %q not to match", name)
+				}
+			}
+		})
+	}
+}
+
+func TestSysctls_ContainerAndAnnotationSources(t *testing.T) {
+	tests := []struct {
+		name    string
+		pod     *corev1.Pod
+		opts    options
+		allowed bool
+	}{
+		{
+			name: "legacy annotation with forbidden sysctl",
+			pod: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					"security.alpha.kubernetes.io/unsafe-sysctls": "kernel.msgmax=65536",
+				},
+			}},
+			allowed: false,
+		},
+		{
+			name: "legacy annotation with allowed sysctl",
+			pod: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					"security.alpha.kubernetes.io/unsafe-sysctls": "kernel.shm_rmid_forced",
+				},
+			}},
+			allowed: true,
+		},
+		{
+			name: "container extractor reports forbidden sysctl",
+			pod: &corev1.Pod{Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "a"}},
+			}},
+			opts: options{
+				containerSysctlExtractor: func(c *corev1.Container) []string {
+					return []string{"kernel.msgmax"}
+				},
+			},
+			allowed: false,
+		},
+		{
+			name: "ephemeral container extractor reports forbidden sysctl",
+			pod: &corev1.Pod{Spec: corev1.PodSpec{
+				EphemeralContainers: []corev1.EphemeralContainer{
+					{EphemeralContainerCommon: corev1.EphemeralContainerCommon{Name: "debug"}},
+				},
+			}},
+			opts: options{
+				containerSysctlExtractor: func(c *corev1.Container) []string {
+					return []string{"kernel.msgmax"}
+				},
+			},
+			allowed: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result := sysctls_1_0(&tc.pod.ObjectMeta, &tc.pod.Spec, tc.opts)
+			if result.Allowed != tc.allowed {
+				t.Errorf("expected Allowed=%v, got Allowed=%v (%s: %s)", tc.allowed, result.Allowed, result.ForbiddenReason, result.ForbiddenDetail)
+			}
+		})
+	}
+}
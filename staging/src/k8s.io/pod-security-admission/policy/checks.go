@@ -0,0 +1,181 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/pod-security-admission/api"
+)
+
+// CheckID uniquely identifies a registered Check, e.g. "hostPorts" or
+// "seccompProfile_baseline". A VersionedCheck.OverrideCheckIDs entry
+// referencing a CheckID suppresses that other Check when both apply to the
+// same evaluation.
+type CheckID string
+
+// CheckResult is the outcome of running a single VersionedCheck's CheckPod
+// against a pod.
+type CheckResult struct {
+	Allowed         bool
+	ForbiddenReason string
+	ForbiddenDetail string
+	ErrList         field.ErrorList
+}
+
+// CheckPodFn validates podMetadata/podSpec against a single VersionedCheck,
+// as configured by opts.
+type CheckPodFn func(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, opts ...Option) CheckResult
+
+// PatchedField describes one field a PatchPodFn changed. Field is a
+// human-readable identifier (e.g. "pod securityContext.runAsNonRoot"); Detail
+// explains what was done to it, mirroring policy/patch.Change.
+type PatchedField struct {
+	Field  string
+	Detail string
+}
+
+// PatchPodFn mutates podSpec in place to satisfy the VersionedCheck it's
+// attached to, as configured by opts, returning a PatchedField per field
+// changed. It is the PatchPod counterpart to CheckPodFn: where CheckPodFn
+// only reports a violation, PatchPodFn fixes it, for use by the
+// policy/patch auto-remediation mode. A VersionedCheck with a nil PatchPod
+// simply isn't auto-remediable yet.
+type PatchPodFn func(podSpec *corev1.PodSpec, opts ...Option) []PatchedField
+
+// VersionedCheck pins a CheckPod (and, where available, a PatchPod)
+// implementation to the minimum Kubernetes version it applies from.
+// OverrideCheckIDs lists other Checks this one supersedes when both would
+// otherwise apply to the same level/version (e.g. a Hardened check
+// overriding its Restricted counterpart), so only the stricter one runs.
+type VersionedCheck struct {
+	MinimumVersion   api.Version
+	CheckPod         CheckPodFn
+	PatchPod         PatchPodFn
+	OverrideCheckIDs []CheckID
+}
+
+// Check is a single named policy rule, registered once via addCheck and
+// potentially implemented differently across Kubernetes releases via
+// Versions.
+type Check struct {
+	ID       CheckID
+	Level    api.Level
+	Versions []VersionedCheck
+}
+
+// registeredChecks holds every Check constructor registered via addCheck.
+// Checks register themselves from an init() in their own check_*.go file,
+// so this is populated by package initialization before any caller can
+// observe it.
+var registeredChecks []func() Check
+
+// addCheck registers newCheck so ChecksForLevel (and, in turn,
+// policy/patch.Patch) can find it. Checks are stored as constructors rather
+// than values so every caller gets its own fresh Versions slice.
+func addCheck(newCheck func() Check) {
+	registeredChecks = append(registeredChecks, newCheck)
+}
+
+// levelRank orders Levels from least to most restrictive. A pod conforming
+// to a given level also conforms to every level ranked below it, so
+// ChecksForLevel includes every registered Check at or below the requested
+// level, not just the ones registered exactly at it.
+var levelRank = map[api.Level]int{
+	api.LevelPrivileged: 0,
+	api.LevelBaseline:   1,
+	api.LevelRestricted: 2,
+	api.LevelHardened:   3,
+}
+
+// SelectedCheck is a VersionedCheck as returned by ChecksForLevel, along
+// with the CheckID and Level of the Check it belongs to. VersionedCheck
+// alone doesn't carry either (a Check can register several VersionedChecks
+// across releases), so callers that attribute a CheckResult to a specific
+// check — such as report.Result — need this pairing.
+type SelectedCheck struct {
+	ID    CheckID
+	Level api.Level
+	VersionedCheck
+}
+
+// ChecksForLevel returns the VersionedCheck applicable at lv.Version from
+// every registered Check at or below lv.Level, excluding any Check whose ID
+// appears in another selected VersionedCheck's OverrideCheckIDs. This is
+// the registry-driven selection policy/patch.Patch and cmd/psa-scan both
+// need: a third-party check registered via addCheck is picked up here the
+// same way a built-in one is, with no call site needing to name it.
+func ChecksForLevel(lv api.LevelVersion) []SelectedCheck {
+	targetRank, ok := levelRank[lv.Level]
+	if !ok {
+		return nil
+	}
+
+	type candidate struct {
+		id CheckID
+		sc SelectedCheck
+	}
+	var candidates []candidate
+	overridden := map[CheckID]bool{}
+
+	for _, newCheck := range registeredChecks {
+		c := newCheck()
+		if levelRank[c.Level] > targetRank {
+			continue
+		}
+		vc, ok := latestApplicableVersion(c.Versions, lv.Version)
+		if !ok {
+			continue
+		}
+		candidates = append(candidates, candidate{
+			id: c.ID,
+			sc: SelectedCheck{ID: c.ID, Level: c.Level, VersionedCheck: vc},
+		})
+		for _, overrideID := range vc.OverrideCheckIDs {
+			overridden[overrideID] = true
+		}
+	}
+
+	var out []SelectedCheck
+	for _, c := range candidates {
+		if overridden[c.id] {
+			continue
+		}
+		out = append(out, c.sc)
+	}
+	return out
+}
+
+// latestApplicableVersion returns the entry in versions with the largest
+// MinimumVersion that is not newer than v, the same "latest version-pinned
+// implementation not past v" rule CheckSysctls' two Versions entries (1.0
+// and 1.27) rely on.
+func latestApplicableVersion(versions []VersionedCheck, v api.Version) (VersionedCheck, bool) {
+	var best VersionedCheck
+	found := false
+	for _, vc := range versions {
+		if v.Older(vc.MinimumVersion) {
+			continue
+		}
+		if !found || best.MinimumVersion.Older(vc.MinimumVersion) {
+			best = vc
+			found = true
+		}
+	}
+	return best, found
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MutatePodFn is the mutation-mode counterpart to CheckPodFn: instead of
+// accepting or rejecting a pod, it rewrites podSpec to satisfy the
+// corresponding check (or, in dry-run mode, reports the mutation it would
+// have made without applying it). This gives an admission plugin a
+// "fix on admit" mutating webhook path alongside the existing validating
+// CheckPodFn one.
+type MutatePodFn func(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, opts ...Option) MutateResult
+
+// JSONPatchOperation is a single RFC 6902 JSON Patch operation, as returned
+// by MutateResult.Patch so a caller (a mutating webhook response, or a
+// dry-run CLI) can apply or display the mutation without the MutatePodFn
+// call itself having to mutate podSpec.
+type JSONPatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// MutateResult is the result of a MutatePodFn call.
+type MutateResult struct {
+	// Mutated reports whether podSpec was changed, or, under
+	// RestrictedVolumesDryRun, would have been.
+	Mutated bool
+	// Changes describes each change made, mirroring
+	// policy/patch.Report.Changes' one-entry-per-field convention.
+	Changes []string
+	// Patch is the sequence of JSON Patch operations equivalent to Changes,
+	// in application order.
+	Patch []JSONPatchOperation
+}
+
+// withMutateOptions adapts an opts-aware mutator function into a
+// MutatePodFn, the same way withOptions adapts a CheckPod function into a
+// CheckPodFn.
+func withMutateOptions(f func(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, opts options) MutateResult) MutatePodFn {
+	return func(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, opts ...Option) MutateResult {
+		return f(podMetadata, podSpec, ResolveOptions(opts...))
+	}
+}
+
+// NFSToPVCClaimName computes the PersistentVolumeClaim name
+// MutateRestrictedVolumes should rewrite an inline nfs volume named
+// volumeName to reference, in place of dropping it outright. Installed with
+// WithNFSToPVCClaimName. Creating and binding the referenced
+// PersistentVolumeClaim object is the caller's responsibility:
+// MutateRestrictedVolumes only has the PodSpec to work with, not a client to
+// create objects with.
+type NFSToPVCClaimName func(volumeName string) string
+
+// WithNFSToPVCClaimName configures MutateRestrictedVolumes to rewrite an
+// inline nfs volume into a persistentVolumeClaim reference using claimName,
+// instead of dropping the volume. Without this option, nfs volumes are
+// dropped like every other restricted volume type.
+func WithNFSToPVCClaimName(claimName NFSToPVCClaimName) Option {
+	return func(opt *options) {
+		opt.nfsToPVCClaimName = claimName
+	}
+}
+
+// RestrictedVolumesMutateMode selects how MutateRestrictedVolumes applies
+// its mutation.
+type RestrictedVolumesMutateMode int
+
+const (
+	// RestrictedVolumesApply mutates podSpec in place.
+	RestrictedVolumesApply RestrictedVolumesMutateMode = iota
+	// RestrictedVolumesDryRun computes the same MutateResult, including
+	// Patch, without modifying podSpec.
+	RestrictedVolumesDryRun
+)
+
+// MutateRestrictedVolumes returns a MutatePodFn that is the mutation-mode
+// counterpart to CheckRestrictedVolumes: every volume not in
+// restrictedVolumesSafeTypes is either rewritten (currently, only an inline
+// nfs volume, via WithNFSToPVCClaimName) or dropped outright, along with any
+// volumeMounts in spec.containers/spec.initContainers that reference a
+// dropped volume's name.
+func MutateRestrictedVolumes(mode RestrictedVolumesMutateMode) MutatePodFn {
+	return withMutateOptions(func(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, opts options) MutateResult {
+		target := podSpec
+		if mode == RestrictedVolumesDryRun {
+			target = podSpec.DeepCopy()
+		}
+		return mutateRestrictedVolumes(target, opts)
+	})
+}
+
+func mutateRestrictedVolumes(podSpec *corev1.PodSpec, opts options) MutateResult {
+	var result MutateResult
+	dropped := map[string]bool{}
+	var removedVolumeIndices []int
+	var keptVolumes []corev1.Volume
+
+	for i, volume := range podSpec.Volumes {
+		volumeType := volumeTypeName(volume)
+		if restrictedVolumesSafeTypes.Has(volumeType) {
+			keptVolumes = append(keptVolumes, volume)
+			continue
+		}
+
+		if volumeType == "nfs" && opts.nfsToPVCClaimName != nil {
+			claimName := opts.nfsToPVCClaimName(volume.Name)
+			rewritten := volume
+			rewritten.VolumeSource = corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claimName},
+			}
+			keptVolumes = append(keptVolumes, rewritten)
+			result.Mutated = true
+			result.Changes = append(result.Changes, fmt.Sprintf(
+				"spec.volumes[%d] %q: rewrote nfs volume to persistentVolumeClaim %q", i, volume.Name, claimName))
+			result.Patch = append(result.Patch, JSONPatchOperation{
+				Op:    "replace",
+				Path:  fmt.Sprintf("/spec/volumes/%d", i),
+				Value: rewritten,
+			})
+			continue
+		}
+
+		dropped[volume.Name] = true
+		removedVolumeIndices = append(removedVolumeIndices, i)
+		result.Mutated = true
+		result.Changes = append(result.Changes, fmt.Sprintf(
+			"spec.volumes[%d] %q: dropped restricted %s volume", i, volume.Name, volumeType))
+	}
+	podSpec.Volumes = keptVolumes
+
+	// JSON Patch "remove" operations must be emitted highest-index-first, so
+	// that applying them in order never invalidates a later operation's
+	// index.
+	for i := len(removedVolumeIndices) - 1; i >= 0; i-- {
+		result.Patch = append(result.Patch, JSONPatchOperation{
+			Op:   "remove",
+			Path: fmt.Sprintf("/spec/volumes/%d", removedVolumeIndices[i]),
+		})
+	}
+
+	if len(dropped) > 0 {
+		dropMounts := func(field string, containers []corev1.Container) {
+			for ci := range containers {
+				var removedMountIndices []int
+				var keptMounts []corev1.VolumeMount
+				for mi, mount := range containers[ci].VolumeMounts {
+					if !dropped[mount.Name] {
+						keptMounts = append(keptMounts, mount)
+						continue
+					}
+					removedMountIndices = append(removedMountIndices, mi)
+					result.Mutated = true
+					result.Changes = append(result.Changes, fmt.Sprintf(
+						"%s[%d].volumeMounts[%d] %q: removed, referenced dropped volume %q",
+						field, ci, mi, containers[ci].Name, mount.Name))
+				}
+				containers[ci].VolumeMounts = keptMounts
+				for i := len(removedMountIndices) - 1; i >= 0; i-- {
+					result.Patch = append(result.Patch, JSONPatchOperation{
+						Op:   "remove",
+						Path: fmt.Sprintf("/spec/%s/%d/volumeMounts/%d", field, ci, removedMountIndices[i]),
+					})
+				}
+			}
+		}
+		dropMounts("initContainers", podSpec.InitContainers)
+		dropMounts("containers", podSpec.Containers)
+	}
+
+	return result
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestMutateRestrictedVolumesDropsAndCleansMounts(t *testing.T) {
+	pod := &corev1.Pod{Spec: corev1.PodSpec{
+		Volumes: []corev1.Volume{
+			{Name: "safe", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+			{Name: "bad", VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{}}},
+		},
+		Containers: []corev1.Container{
+			{Name: "c", VolumeMounts: []corev1.VolumeMount{
+				{Name: "safe", MountPath: "/safe"},
+				{Name: "bad", MountPath: "/bad"},
+			}},
+		},
+	}}
+
+	mutate := MutateRestrictedVolumes(RestrictedVolumesApply)
+	result := mutate(&pod.ObjectMeta, &pod.Spec)
+
+	if !result.Mutated {
+		t.Fatalf("expected Mutated=true")
+	}
+	if len(pod.Spec.Volumes) != 1 || pod.Spec.Volumes[0].Name != "safe" {
+		t.Errorf("expected only the safe volume to remain, got %+v", pod.Spec.Volumes)
+	}
+	if len(pod.Spec.Containers[0].VolumeMounts) != 1 || pod.Spec.Containers[0].VolumeMounts[0].Name != "safe" {
+		t.Errorf("expected only the safe volumeMount to remain, got %+v", pod.Spec.Containers[0].VolumeMounts)
+	}
+}
+
+func TestMutateRestrictedVolumesDryRunDoesNotMutate(t *testing.T) {
+	pod := &corev1.Pod{Spec: corev1.PodSpec{
+		Volumes: []corev1.Volume{
+			{Name: "bad", VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{}}},
+		},
+	}}
+
+	mutate := MutateRestrictedVolumes(RestrictedVolumesDryRun)
+	result := mutate(&pod.ObjectMeta, &pod.Spec)
+
+	if !result.Mutated {
+		t.Fatalf("expected Mutated=true to report what would change")
+	}
+	if len(pod.Spec.Volumes) != 1 {
+		t.Errorf("expected dry-run to leave podSpec.Volumes untouched, got %+v", pod.Spec.Volumes)
+	}
+	if len(result.Patch) != 1 || result.Patch[0].Op != "remove" || result.Patch[0].Path != "/spec/volumes/0" {
+		t.Errorf("unexpected Patch: %+v", result.Patch)
+	}
+}
+
+func TestMutateRestrictedVolumesRewritesNFSToPVC(t *testing.T) {
+	pod := &corev1.Pod{Spec: corev1.PodSpec{Volumes: []corev1.Volume{
+		{Name: "data", VolumeSource: corev1.VolumeSource{NFS: &corev1.NFSVolumeSource{Server: "fileserver", Path: "/export"}}},
+	}}}
+
+	mutate := MutateRestrictedVolumes(RestrictedVolumesApply)
+	result := mutate(&pod.ObjectMeta, &pod.Spec, WithNFSToPVCClaimName(func(volumeName string) string {
+		return volumeName + "-pvc"
+	}))
+
+	if !result.Mutated {
+		t.Fatalf("expected Mutated=true")
+	}
+	got := pod.Spec.Volumes[0].VolumeSource.PersistentVolumeClaim
+	if got == nil || got.ClaimName != "data-pvc" {
+		t.Errorf("expected volume rewritten to a persistentVolumeClaim named %q, got %+v", "data-pvc", pod.Spec.Volumes[0].VolumeSource)
+	}
+}
+
+func TestMutateRestrictedVolumesNoOffendingVolumes(t *testing.T) {
+	pod := &corev1.Pod{Spec: corev1.PodSpec{Volumes: []corev1.Volume{
+		{Name: "safe", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+	}}}
+
+	mutate := MutateRestrictedVolumes(RestrictedVolumesApply)
+	result := mutate(&pod.ObjectMeta, &pod.Spec)
+
+	if result.Mutated {
+		t.Errorf("expected Mutated=false when nothing needs to change, got true (%v)", result.Changes)
+	}
+	if len(pod.Spec.Volumes) != 1 {
+		t.Errorf("expected the safe volume to be left alone, got %+v", pod.Spec.Volumes)
+	}
+}
@@ -17,17 +17,266 @@ limitations under the License.
 package policy
 
 import (
+	"strings"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 type options struct {
-	withErrList bool
+	withErrList     bool
+	withFieldErrors bool
+
+	// extraAllowedSysctls is unioned with the version-pinned allow-list in
+	// CheckSysctls, letting operators align the admission policy with
+	// kubelet's --allowed-unsafe-sysctls without forking the check.
+	extraAllowedSysctls sets.String
+	// extraAllowedSELinuxTypes is unioned with the version-pinned allow-list
+	// in CheckSELinuxOptions.
+	extraAllowedSELinuxTypes sets.String
+	// allowedSELinuxUsers and allowedSELinuxRoles permit specific
+	// seLinuxOptions.user/role values instead of forbidding them outright.
+	allowedSELinuxUsers sets.String
+	allowedSELinuxRoles sets.String
+
+	// exemptImages is a list of glob/prefix patterns (a single trailing "*"
+	// is supported) matched against a container's Image. A matching
+	// container is skipped, without emitting a violation, by
+	// CheckSELinuxOptions and by CheckSysctls' container-level sysctl
+	// extraction; other checks do not consult it.
+	exemptImages []string
+
+	// containerSysctlExtractor plugs container- or annotation-sourced
+	// sysctl mechanisms into CheckSysctls, so defense-in-depth coverage can
+	// be added without the check needing to know about every vendor
+	// extension. nil means no container-level sysctls are considered.
+	containerSysctlExtractor ContainerSysctlExtractor
+
+	// allowedAddCapabilities is unioned with the NET_BIND_SERVICE default in
+	// CheckCapabilitiesRestricted, letting operators exempt e.g. a
+	// debugging namespace that needs SYS_PTRACE.
+	allowedAddCapabilities sets.String
+	// allowedHostPorts is consulted by CheckHostPorts in addition to the
+	// default of forbidding every hostPort.
+	allowedHostPorts []PortRange
+	// allowedLocalhostSeccompProfiles restricts which
+	// seccompProfile.localhostProfile names CheckSeccompBaseline accepts.
+	// Empty means any localhost profile remains acceptable, matching
+	// today's default behavior.
+	allowedLocalhostSeccompProfiles sets.String
+
+	// includeEphemeral controls whether visitContainers also visits
+	// spec.ephemeralContainers. A nil value means the default of true:
+	// ephemeral containers have historically been able to bypass
+	// Baseline/Restricted checks that forgot to consider them (e.g. a
+	// debug container injected with capabilities.add=["SYS_ADMIN"]), so
+	// checks include them unless a caller opts out.
+	includeEphemeral *bool
+
+	// volumePolicy, compiled via CompileVolumePolicy and installed with
+	// WithVolumePolicy, lets CheckRestrictedVolumesPolicy accept otherwise
+	// restricted volume types under operator-declared conditions instead of
+	// CheckRestrictedVolumes' fixed allow-list. nil means no policy is
+	// configured.
+	volumePolicy *CompiledVolumePolicy
+	// namespaceLabels is supplied per-request, since the pod's namespace
+	// object isn't resolvable from podMetadata/podSpec alone, so a
+	// volumePolicy rule's namespaceSelector condition has something to
+	// match against.
+	namespaceLabels labels.Set
+	// pvcStorageClassLookup resolves the storageClassName of a referenced
+	// PersistentVolumeClaim, so a volumePolicy rule's storageClassNames
+	// condition can match inline persistentVolumeClaim volumes. nil means
+	// storageClassNames conditions never match.
+	pvcStorageClassLookup PersistentVolumeClaimStorageClassLookup
+
+	// nfsToPVCClaimName, installed with WithNFSToPVCClaimName, tells
+	// MutateRestrictedVolumes to rewrite an inline nfs volume into a
+	// persistentVolumeClaim reference instead of dropping it. nil means nfs
+	// volumes are dropped like every other restricted volume type.
+	nfsToPVCClaimName NFSToPVCClaimName
+}
+
+// includeEphemeralContainers reports whether visitContainers should also
+// visit spec.ephemeralContainers, defaulting to true.
+func (o options) includeEphemeralContainers() bool {
+	return o.includeEphemeral == nil || *o.includeEphemeral
+}
+
+// imageExempt reports whether image matches one of the configured
+// exemptImages patterns.
+func (o options) imageExempt(image string) bool {
+	for _, pattern := range o.exemptImages {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(image, prefix) {
+				return true
+			}
+		} else if image == pattern {
+			return true
+		}
+	}
+	return false
 }
 
 type Option func(*options)
 
+// WithExtraAllowedSysctls registers additional sysctl names that are
+// permitted in addition to the version-pinned allow-list used by
+// CheckSysctls.
+func WithExtraAllowedSysctls(names ...string) Option {
+	return func(opt *options) {
+		if opt.extraAllowedSysctls == nil {
+			opt.extraAllowedSysctls = sets.NewString()
+		}
+		opt.extraAllowedSysctls.Insert(names...)
+	}
+}
+
+// WithExtraAllowedSELinuxTypes registers additional seLinuxOptions.type
+// values that are permitted in addition to the version-pinned allow-list
+// used by CheckSELinuxOptions.
+func WithExtraAllowedSELinuxTypes(types ...string) Option {
+	return func(opt *options) {
+		if opt.extraAllowedSELinuxTypes == nil {
+			opt.extraAllowedSELinuxTypes = sets.NewString()
+		}
+		opt.extraAllowedSELinuxTypes.Insert(types...)
+	}
+}
+
+// WithAllowedSELinuxUsers permits the given seLinuxOptions.user values
+// instead of forbidding user from being set at all.
+func WithAllowedSELinuxUsers(users ...string) Option {
+	return func(opt *options) {
+		if opt.allowedSELinuxUsers == nil {
+			opt.allowedSELinuxUsers = sets.NewString()
+		}
+		opt.allowedSELinuxUsers.Insert(users...)
+	}
+}
+
+// WithAllowedSELinuxRoles permits the given seLinuxOptions.role values
+// instead of forbidding role from being set at all.
+func WithAllowedSELinuxRoles(roles ...string) Option {
+	return func(opt *options) {
+		if opt.allowedSELinuxRoles == nil {
+			opt.allowedSELinuxRoles = sets.NewString()
+		}
+		opt.allowedSELinuxRoles.Insert(roles...)
+	}
+}
+
+// WithContainerSysctlExtractor registers a ContainerSysctlExtractor so
+// CheckSysctls also validates sysctls surfaced through container-level or
+// annotation-based mechanisms that spec.securityContext.sysctls misses.
+func WithContainerSysctlExtractor(extractor ContainerSysctlExtractor) Option {
+	return func(opt *options) {
+		opt.containerSysctlExtractor = extractor
+	}
+}
+
+// WithAllowedAddCapabilities registers additional capability names that
+// CheckCapabilitiesRestricted permits in securityContext.capabilities.add,
+// alongside the always-allowed NET_BIND_SERVICE.
+func WithAllowedAddCapabilities(capabilities ...string) Option {
+	return func(opt *options) {
+		if opt.allowedAddCapabilities == nil {
+			opt.allowedAddCapabilities = sets.NewString()
+		}
+		opt.allowedAddCapabilities.Insert(capabilities...)
+	}
+}
+
+// WithAllowedHostPorts registers port ranges that CheckHostPorts permits in
+// addition to its default of forbidding every hostPort.
+func WithAllowedHostPorts(ranges ...PortRange) Option {
+	return func(opt *options) {
+		opt.allowedHostPorts = append(opt.allowedHostPorts, ranges...)
+	}
+}
+
+// WithAllowedLocalhostSeccompProfiles restricts the
+// seccompProfile.localhostProfile names CheckSeccompBaseline accepts to the
+// given set. If never called, any localhost profile remains acceptable.
+func WithAllowedLocalhostSeccompProfiles(profiles ...string) Option {
+	return func(opt *options) {
+		if opt.allowedLocalhostSeccompProfiles == nil {
+			opt.allowedLocalhostSeccompProfiles = sets.NewString()
+		}
+		opt.allowedLocalhostSeccompProfiles.Insert(profiles...)
+	}
+}
+
+// WithIncludeEphemeral controls whether checks also validate
+// spec.ephemeralContainers, in addition to spec.containers and
+// spec.initContainers. It defaults to true; pass false to exclude ephemeral
+// containers, e.g. when they are evaluated separately by a caller that
+// re-checks the pod specifically for an update to the ephemeralcontainers
+// subresource.
+func WithIncludeEphemeral(include bool) Option {
+	return func(opt *options) {
+		opt.includeEphemeral = &include
+	}
+}
+
+// WithVolumePolicy installs a CompiledVolumePolicy (built by
+// CompileVolumePolicy) for CheckRestrictedVolumesPolicy to evaluate in place
+// of CheckRestrictedVolumes' fixed volume-type allow-list.
+func WithVolumePolicy(policy *CompiledVolumePolicy) Option {
+	return func(opt *options) {
+		opt.volumePolicy = policy
+	}
+}
+
+// WithNamespaceLabels supplies the labels of the pod's namespace for the
+// current admission request, so a WithVolumePolicy rule's namespaceSelector
+// condition can be evaluated. Callers resolve this from whatever namespace
+// lister they already have; CheckPod itself only ever sees the Pod being
+// admitted.
+func WithNamespaceLabels(namespaceLabels map[string]string) Option {
+	return func(opt *options) {
+		opt.namespaceLabels = namespaceLabels
+	}
+}
+
+// WithPersistentVolumeClaimStorageClassLookup installs a lookup used by a
+// WithVolumePolicy rule's storageClassNames condition to resolve the
+// spec.storageClassName of a referenced PersistentVolumeClaim.
+func WithPersistentVolumeClaimStorageClassLookup(lookup PersistentVolumeClaimStorageClassLookup) Option {
+	return func(opt *options) {
+		opt.pvcStorageClassLookup = lookup
+	}
+}
+
+// WithExemptImages configures glob/prefix patterns (a single trailing "*" is
+// supported, matching the kubelet --allowed-unsafe-sysctls convention) that
+// exempt a matching container from CheckSELinuxOptions' container-level
+// validation and from CheckSysctls' container-level sysctl extraction. Other
+// per-container checks do not consult these patterns.
+func WithExemptImages(patterns ...string) Option {
+	return func(opt *options) {
+		opt.exemptImages = append(opt.exemptImages, patterns...)
+	}
+}
+
+// ResolveOptions applies a set of Option values and returns the resulting
+// options. It is exported so that code outside this package that drives
+// PatchPod-style functions directly (such as policy/patch) can share the
+// same option resolution CheckPod uses, without needing to name the
+// unexported options type.
+func ResolveOptions(opts ...Option) options {
+	var opt options
+	for _, o := range opts {
+		if o != nil {
+			o(&opt)
+		}
+	}
+	return opt
+}
+
 func withOptions(f func(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, opts options) CheckResult) CheckPodFn {
 	return func(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, opts ...Option) CheckResult {
 		var opt options
@@ -40,6 +289,17 @@ func withOptions(f func(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec,
 	}
 }
 
+// withPatchOptions adapts an unexported PatchPod implementation (taking a
+// resolved options value, the same way every other Patch* function in this
+// package already does) into the PatchPodFn shape VersionedCheck.PatchPod
+// exposes publicly, resolving opts the same way withOptions does for
+// CheckPodFn.
+func withPatchOptions(f func(podSpec *corev1.PodSpec, opts options) []PatchedField) PatchPodFn {
+	return func(podSpec *corev1.PodSpec, opts ...Option) []PatchedField {
+		return f(podSpec, ResolveOptions(opts...))
+	}
+}
+
 type ErrListHandler func(errList *field.ErrorList, error *field.Error)
 
 func (o options) errListHandler(f func()) {
@@ -50,8 +310,18 @@ func (o options) errListHandler(f func()) {
 	}
 }
 
+// WithErrList turns on field.ErrorList collection for every check, both the
+// errListHandler-gated checks (hostPorts, seccomp, restrictedVolumes, the
+// hardened checks) and the violations[T]-based ones (sysctls,
+// seLinuxOptions, capabilities_restricted, runAsNonRoot, runAsUser), which
+// gate their field paths on withFieldErrors rather than withErrList. There
+// is deliberately no separate WithFieldErrors: collecting field errors only
+// makes sense alongside the ErrList they get attached to, so one option
+// sets both rather than asking callers to enable two flags to get one
+// feature.
 func WithErrList() Option {
 	return func(opt *options) {
 		opt.withErrList = true
+		opt.withFieldErrors = true
 	}
 }
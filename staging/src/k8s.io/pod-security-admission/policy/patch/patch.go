@@ -0,0 +1,81 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package patch implements an auto-remediation ("mutate") mode for Pod
+// Security Admission: given a PodSpec and a target policy level/version, it
+// returns a copy of the spec mutated to satisfy the corresponding checks,
+// along with a report describing what changed.
+//
+// This complements the validating CheckPod path in the policy package:
+// where CheckPod rejects a non-conforming pod, Patch mutates it into
+// conformance, giving operators a migration path from Privileged to
+// Baseline without rejecting workloads outright.
+package patch
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	"k8s.io/pod-security-admission/api"
+	"k8s.io/pod-security-admission/policy"
+)
+
+// Change describes a single field that auto-remediation modified.
+type Change struct {
+	// Field is a human-readable identifier of what was changed, e.g.
+	// "pod securityContext.seLinuxOptions.user".
+	Field string
+	// Detail explains what value was removed or reset.
+	Detail string
+}
+
+// Report summarizes the changes a Patch call made to a PodSpec.
+type Report struct {
+	Changes []Change
+}
+
+// Empty reports whether Patch made no changes.
+func (r Report) Empty() bool {
+	return len(r.Changes) == 0
+}
+
+// Patch returns a copy of podSpec mutated to satisfy the built-in checks for
+// lv.Level at lv.Version, along with a Report describing what was changed.
+// podSpec itself is not modified.
+//
+// Patch iterates policy.ChecksForLevel(lv) and invokes each VersionedCheck's
+// PatchPod, in registration order, skipping checks that don't provide one
+// (not every check has a meaningful auto-remediation, e.g. privileged or
+// hostNamespaces). Because the registry drives this, a check registered via
+// policy.addCheck with a PatchPod field is picked up automatically — there
+// is no hardcoded list of policy.Patch* functions to keep in sync. opts
+// configures the same allowlists (e.g. WithAllowedHostPorts) that the
+// validating CheckPod path accepts, so a patched pod and a subsequently
+// evaluated one agree.
+func Patch(podSpec *corev1.PodSpec, lv api.LevelVersion, opts ...policy.Option) (*corev1.PodSpec, Report) {
+	out := podSpec.DeepCopy()
+	var report Report
+
+	for _, vc := range policy.ChecksForLevel(lv) {
+		if vc.PatchPod == nil {
+			continue
+		}
+		for _, field := range vc.PatchPod(out, opts...) {
+			report.Changes = append(report.Changes, Change{Field: field.Field, Detail: field.Detail})
+		}
+	}
+
+	return out, report
+}
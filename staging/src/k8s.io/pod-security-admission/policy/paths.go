@@ -88,3 +88,17 @@ func (parent PathFn) key(key string) PathFn {
 		return p.Key(key)
 	}
 }
+
+// resolve calls pathFn and returns its *field.Path, or nil without calling
+// it if pathFn itself is nil. visitContainers passes a nil PathFn when
+// opts.withFieldErrors is false (e.g. only opts.withErrList was set), and
+// pathFn is itself a func value, so invoking it directly (pathFn()) panics
+// in that case; callers building a path from a container-level pathFn
+// inside opts.errListHandler must go through resolve instead of calling the
+// chain's result directly.
+func (pathFn PathFn) resolve() *field.Path {
+	if pathFn == nil {
+		return nil
+	}
+	return pathFn()
+}
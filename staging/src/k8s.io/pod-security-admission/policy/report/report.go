@@ -0,0 +1,284 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package report renders Pod Security Admission check results into
+// machine-readable formats: SARIF 2.1.0, for tools (such as GitHub's code
+// scanning tab) that consume static analysis results, and a compact JSON
+// form for IDE plugins that don't want to parse SARIF.
+//
+// This complements the human-readable ForbiddenReason/ForbiddenDetail
+// strings CheckResult already carries: those are meant to go straight into
+// an admission response, while this package is for offline scanning of
+// manifests in CI, where a structured, per-violation result is more useful
+// than one aggregate message.
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/pod-security-admission/api"
+	"k8s.io/pod-security-admission/policy"
+)
+
+// Result pairs a policy.CheckResult with the identity of the check that
+// produced it. CheckResult alone doesn't carry this (a Check can register
+// several CheckPod versions across Kubernetes releases), so callers
+// driving policy.ChecksForLevel (such as cmd/psa-scan) assemble one Result
+// per VersionedCheck they ran.
+type Result struct {
+	// CheckID is the Check.ID of the check that produced Result.
+	CheckID policy.CheckID
+	// Level is the policy level (Baseline or Restricted) the check belongs to.
+	Level api.Level
+	// Version is the minimum Kubernetes version of the VersionedCheck that
+	// produced Result.
+	Version api.Version
+
+	policy.CheckResult
+}
+
+// sarifVersion is the SARIF schema version this package emits.
+const sarifVersion = "2.1.0"
+
+const sarifSchemaURI = "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json"
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules,omitempty"`
+}
+
+type sarifRule struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation  `json:"physicalLocation"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifLogicalLocation struct {
+	// FullyQualifiedName holds the field.Error.Field JSON path (e.g.
+	// "spec.containers[0].securityContext.capabilities.add") since SARIF
+	// has no first-class notion of a JSON path into a non-text artifact.
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// podURI returns a synthetic artifact URI identifying pod. FormatSARIF has
+// no access to the manifest's source file, so callers that need a real
+// file-relative URI (e.g. cmd/psa-scan, which reads the manifest off disk)
+// should post-process Locations[].PhysicalLocation.ArtifactLocation.URI.
+func podURI(pod metav1.Object) string {
+	if pod.GetNamespace() == "" {
+		return fmt.Sprintf("pods/%s", pod.GetName())
+	}
+	return fmt.Sprintf("pods/%s/%s", pod.GetNamespace(), pod.GetName())
+}
+
+// sarifLevel maps a policy level to a SARIF result level. Restricted
+// violations are reported as "error" and Baseline violations as "warning",
+// matching the two levels' intent: Baseline is a minimally-permissive
+// floor, Restricted is the hardening target.
+func sarifLevel(level api.Level) string {
+	if level == api.LevelRestricted {
+		return "error"
+	}
+	return "warning"
+}
+
+// FormatSARIF renders results as a SARIF 2.1.0 log with a single run. Only
+// results with Allowed == false produce a SARIF result; allowed results are
+// omitted, matching how static analysis tools only report findings.
+func FormatSARIF(pod metav1.Object, results []Result) ([]byte, error) {
+	rules := map[string]struct{}{}
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{Name: "pod-security-admission"}},
+	}
+
+	for _, r := range results {
+		if r.Allowed {
+			continue
+		}
+		ruleID := string(r.CheckID)
+		if _, ok := rules[ruleID]; !ok {
+			rules[ruleID] = struct{}{}
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{ID: ruleID, Name: r.ForbiddenReason})
+		}
+
+		var locations []sarifLocation
+		for _, err := range r.ErrList {
+			locations = append(locations, sarifLocation{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: podURI(pod)},
+				},
+				LogicalLocations: []sarifLogicalLocation{
+					{FullyQualifiedName: err.Field, Kind: "member"},
+				},
+			})
+		}
+		if len(locations) == 0 {
+			// No field-level errors were collected (the check was run
+			// without policy.WithErrList); still report at pod granularity
+			// rather than dropping the finding.
+			locations = []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: podURI(pod)},
+				},
+			}}
+		}
+
+		run.Results = append(run.Results, sarifResult{
+			RuleID:    ruleID,
+			Level:     sarifLevel(r.Level),
+			Message:   sarifMessage{Text: r.ForbiddenDetail},
+			Locations: locations,
+		})
+	}
+
+	return json.MarshalIndent(sarifLog{
+		Schema:  sarifSchemaURI,
+		Version: sarifVersion,
+		Runs:    []sarifRun{run},
+	}, "", "  ")
+}
+
+// JSONResult is the compact, per-violation shape FormatJSON emits: flatter
+// than SARIF, for IDE plugins and scripts that just want the fields.
+type JSONResult struct {
+	Namespace string   `json:"namespace,omitempty"`
+	Name      string   `json:"name,omitempty"`
+	CheckID   string   `json:"checkId"`
+	Level     string   `json:"level"`
+	Reason    string   `json:"reason,omitempty"`
+	Detail    string   `json:"detail,omitempty"`
+	Fields    []string `json:"fields,omitempty"`
+}
+
+// StructuredError is a single field.Error out of a Result's ErrList,
+// flattened to plain data with its owning check's identity attached. This is
+// the closest equivalent this package can offer to the
+// EvaluatorResult.StructuredErrors mode downstream tooling (GitOps policy
+// scanners, kubectl plugins) wants: policy.Evaluator, the type that would
+// actually own an EvaluatorResult, isn't part of this checkout (see the
+// cmd/psa-scan package doc), so StructuredErrors works off the same
+// []Result every other Format* function here already consumes.
+//
+// Known deviation from the original request: the request asked for this
+// mode to live on EvaluatorResult itself. Until Evaluator lands here, this
+// package-level function is the adaptation in place of that; anyone relying
+// on an EvaluatorResult.StructuredErrors call site should treat this as a
+// stand-in to replace, not the final shape, and should confirm that's
+// acceptable before merge rather than assuming it.
+type StructuredError struct {
+	CheckID  string      `json:"checkId"`
+	Level    string      `json:"level"`
+	Type     string      `json:"type"`
+	Field    string      `json:"field"`
+	BadValue interface{} `json:"badValue,omitempty"`
+	Detail   string      `json:"detail,omitempty"`
+}
+
+// StructuredErrors flattens every disallowed Result's ErrList into one
+// ordered slice of StructuredError, each carrying the exact field.Path (as a
+// JSONPath-like string, e.g. "spec.containers[0].securityContext.capabilities.add")
+// and BadValue a field.Error records. A Result produced without
+// policy.WithErrList contributes nothing here, since it has no ErrList to
+// flatten.
+func StructuredErrors(results []Result) []StructuredError {
+	var out []StructuredError
+	for _, r := range results {
+		if r.Allowed {
+			continue
+		}
+		for _, err := range r.ErrList {
+			out = append(out, StructuredError{
+				CheckID:  string(r.CheckID),
+				Level:    string(r.Level),
+				Type:     string(err.Type),
+				Field:    err.Field,
+				BadValue: err.BadValue,
+				Detail:   err.Detail,
+			})
+		}
+	}
+	return out
+}
+
+// FormatStructuredErrors renders StructuredErrors(results) as a JSON array.
+func FormatStructuredErrors(results []Result) ([]byte, error) {
+	return json.MarshalIndent(StructuredErrors(results), "", "  ")
+}
+
+// FormatJSON renders results as a compact JSON array, one entry per
+// disallowed Result. Allowed results are omitted, as in FormatSARIF.
+func FormatJSON(pod metav1.Object, results []Result) ([]byte, error) {
+	out := make([]JSONResult, 0, len(results))
+	for _, r := range results {
+		if r.Allowed {
+			continue
+		}
+		jr := JSONResult{
+			Namespace: pod.GetNamespace(),
+			Name:      pod.GetName(),
+			CheckID:   string(r.CheckID),
+			Level:     string(r.Level),
+			Reason:    r.ForbiddenReason,
+			Detail:    r.ForbiddenDetail,
+		}
+		for _, err := range r.ErrList {
+			jr.Fields = append(jr.Fields, err.Field)
+		}
+		out = append(out, jr)
+	}
+	return json.MarshalIndent(out, "", "  ")
+}
@@ -41,6 +41,21 @@ func (v *violations[T]) Add(data T, errFns ...ErrFn) {
 	}
 }
 
+// AddErrs appends field errors without adding a corresponding data entry, for
+// callers that built up a batch of ErrFns (e.g. one per violating field)
+// before they know whether the overall check failed.
+func (v *violations[T]) AddErrs(errFns ...ErrFn) {
+	if v.withFieldErrors {
+		for _, errFn := range errFns {
+			if errFn != nil {
+				if err := errFn(); err != nil {
+					v.errs = append(v.errs, err)
+				}
+			}
+		}
+	}
+}
+
 func (v *violations[T]) Empty() bool {
 	return len(v.data) == 0
 }
@@ -24,7 +24,11 @@ import (
 type ContainerVisitor func(container *corev1.Container, pathFn PathFn)
 
 // visitContainers invokes the visitor function with a pointer to the spec
-// of every container in the given pod spec.
+// of every container in the given pod spec. spec.ephemeralContainers is
+// included unless opts.includeEphemeral (see WithIncludeEphemeral) is set
+// to false, so a check wired through visitContainers also sees e.g. a
+// debug container added via the ephemeralcontainers subresource, rather
+// than only validating it at pod creation.
 func visitContainers(podSpec *corev1.PodSpec, opts options, visitor ContainerVisitor) {
 	for i := range podSpec.InitContainers {
 		if opts.withFieldErrors {
@@ -40,6 +44,9 @@ func visitContainers(podSpec *corev1.PodSpec, opts options, visitor ContainerVis
 			visitor(&podSpec.Containers[i], nil)
 		}
 	}
+	if !opts.includeEphemeralContainers() {
+		return
+	}
 	for i := range podSpec.EphemeralContainers {
 		if opts.withFieldErrors {
 			visitor((*corev1.Container)(&podSpec.EphemeralContainers[i].EphemeralContainerCommon), ephemeralContainersFldPath.index(i))
@@ -0,0 +1,148 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// VolumeTypeChecker decides whether a single inline pod volume source type
+// is allowed under CheckRestrictedVolumes, in place of a hard-coded switch.
+// This lets an out-of-tree build add rules for volume types Kubernetes has
+// since introduced, or CSI-driver-specific constraints (e.g. only allow
+// csi.driver == "ebs.csi.aws.com" with fsType=ext4), by calling
+// RegisterVolumeTypeChecker instead of forking the module.
+type VolumeTypeChecker interface {
+	// Name identifies the volume type this checker handles (e.g. "hostPath",
+	// "nfs", "csi"). Used as the badVolumeTypes entry reported in
+	// CheckResult.ForbiddenDetail.
+	Name() string
+	// Matches reports whether volume is the type this checker handles. Of
+	// the checkers registered via RegisterVolumeTypeChecker, only the first
+	// whose Matches returns true is consulted for a given volume.
+	Matches(volume *corev1.Volume) bool
+	// Allowed reports whether volume is acceptable. A non-nil field.Error is
+	// optional: returning one lets a checker attach its own Field/BadValue
+	// (e.g. pointing at csi.fsType rather than the volume as a whole), which
+	// the caller roots under the volume's spec.volumes[i] index; returning
+	// nil falls back to reporting the whole volume index as forbidden.
+	Allowed(volume *corev1.Volume, opts options) (bool, *field.Error)
+}
+
+// volumeTypeCheckers holds every registered VolumeTypeChecker, in
+// registration order. Built-in types are registered from this file's init(),
+// so an out-of-tree build that wants to replace one (e.g. exempt a specific
+// hostPath) re-registers a checker with the same Name(); anything wanting a
+// genuinely new type just registers a new Name().
+var volumeTypeCheckers []VolumeTypeChecker
+
+// RegisterVolumeTypeChecker adds checker to the set CheckRestrictedVolumes
+// consults. Registering a checker whose Name() matches an already-registered
+// one replaces it in place, preserving its position in the match order;
+// otherwise checker is appended. A custom hostPath rule registered this way
+// still needs its own Check to declare
+// OverrideCheckIDs: []CheckID{checkHostPathVolumesID} the same way
+// CheckRestrictedVolumes already does, since that suppression happens at the
+// Check level, not here.
+//
+// Like every other Check registered in this package, RegisterVolumeTypeChecker
+// is meant to be called from an init() function, before the admission plugin
+// starts handling requests: volumeTypeCheckers isn't synchronized, the same
+// way the Check registry addCheck builds isn't, so calling it concurrently
+// with an in-flight CheckPod/CheckRestrictedVolumes call is unsafe.
+func RegisterVolumeTypeChecker(checker VolumeTypeChecker) {
+	for i, existing := range volumeTypeCheckers {
+		if existing.Name() == checker.Name() {
+			volumeTypeCheckers[i] = checker
+			return
+		}
+	}
+	volumeTypeCheckers = append(volumeTypeCheckers, checker)
+}
+
+// matchVolumeTypeChecker returns the first registered VolumeTypeChecker that
+// matches volume, or nil if none do.
+func matchVolumeTypeChecker(volume *corev1.Volume) VolumeTypeChecker {
+	for _, checker := range volumeTypeCheckers {
+		if checker.Matches(volume) {
+			return checker
+		}
+	}
+	return nil
+}
+
+// volumeFieldChecker is a VolumeTypeChecker for a single inline volume
+// source field that is unconditionally allowed or unconditionally
+// forbidden, covering every type CheckRestrictedVolumes has historically
+// hard-coded in its switch.
+type volumeFieldChecker struct {
+	name    string
+	matches func(volume *corev1.Volume) bool
+	allowed bool
+}
+
+func (c volumeFieldChecker) Name() string { return c.name }
+
+func (c volumeFieldChecker) Matches(volume *corev1.Volume) bool { return c.matches(volume) }
+
+func (c volumeFieldChecker) Allowed(volume *corev1.Volume, opts options) (bool, *field.Error) {
+	return c.allowed, nil
+}
+
+func init() {
+	// Always allowed in the restricted profile.
+	for _, c := range []volumeFieldChecker{
+		{name: "configMap", matches: func(v *corev1.Volume) bool { return v.ConfigMap != nil }, allowed: true},
+		{name: "csi", matches: func(v *corev1.Volume) bool { return v.CSI != nil }, allowed: true},
+		{name: "downwardAPI", matches: func(v *corev1.Volume) bool { return v.DownwardAPI != nil }, allowed: true},
+		{name: "emptyDir", matches: func(v *corev1.Volume) bool { return v.EmptyDir != nil }, allowed: true},
+		{name: "ephemeral", matches: func(v *corev1.Volume) bool { return v.Ephemeral != nil }, allowed: true},
+		{name: "persistentVolumeClaim", matches: func(v *corev1.Volume) bool { return v.PersistentVolumeClaim != nil }, allowed: true},
+		{name: "projected", matches: func(v *corev1.Volume) bool { return v.Projected != nil }, allowed: true},
+		{name: "secret", matches: func(v *corev1.Volume) bool { return v.Secret != nil }, allowed: true},
+	} {
+		RegisterVolumeTypeChecker(c)
+	}
+
+	// Always forbidden in the restricted profile.
+	for _, c := range []volumeFieldChecker{
+		{name: "hostPath", matches: func(v *corev1.Volume) bool { return v.HostPath != nil }},
+		{name: "gcePersistentDisk", matches: func(v *corev1.Volume) bool { return v.GCEPersistentDisk != nil }},
+		{name: "awsElasticBlockStore", matches: func(v *corev1.Volume) bool { return v.AWSElasticBlockStore != nil }},
+		{name: "gitRepo", matches: func(v *corev1.Volume) bool { return v.GitRepo != nil }},
+		{name: "nfs", matches: func(v *corev1.Volume) bool { return v.NFS != nil }},
+		{name: "iscsi", matches: func(v *corev1.Volume) bool { return v.ISCSI != nil }},
+		{name: "glusterfs", matches: func(v *corev1.Volume) bool { return v.Glusterfs != nil }},
+		{name: "rbd", matches: func(v *corev1.Volume) bool { return v.RBD != nil }},
+		{name: "flexVolume", matches: func(v *corev1.Volume) bool { return v.FlexVolume != nil }},
+		{name: "cinder", matches: func(v *corev1.Volume) bool { return v.Cinder != nil }},
+		{name: "cephfs", matches: func(v *corev1.Volume) bool { return v.CephFS != nil }},
+		{name: "flocker", matches: func(v *corev1.Volume) bool { return v.Flocker != nil }},
+		{name: "fc", matches: func(v *corev1.Volume) bool { return v.FC != nil }},
+		{name: "azureFile", matches: func(v *corev1.Volume) bool { return v.AzureFile != nil }},
+		{name: "vsphereVolume", matches: func(v *corev1.Volume) bool { return v.VsphereVolume != nil }},
+		{name: "quobyte", matches: func(v *corev1.Volume) bool { return v.Quobyte != nil }},
+		{name: "azureDisk", matches: func(v *corev1.Volume) bool { return v.AzureDisk != nil }},
+		{name: "photonPersistentDisk", matches: func(v *corev1.Volume) bool { return v.PhotonPersistentDisk != nil }},
+		{name: "portworxVolume", matches: func(v *corev1.Volume) bool { return v.PortworxVolume != nil }},
+		{name: "scaleIO", matches: func(v *corev1.Volume) bool { return v.ScaleIO != nil }},
+		{name: "storageos", matches: func(v *corev1.Volume) bool { return v.StorageOS != nil }},
+	} {
+		RegisterVolumeTypeChecker(c)
+	}
+}
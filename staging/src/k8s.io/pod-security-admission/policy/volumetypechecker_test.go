@@ -0,0 +1,94 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestRestrictedVolumesBuiltinTypes(t *testing.T) {
+	pod := &corev1.Pod{Spec: corev1.PodSpec{Volumes: []corev1.Volume{
+		{Name: "safe", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+		{Name: "bad", VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{}}},
+	}}}
+
+	result := restrictedVolumes_1_0(&pod.ObjectMeta, &pod.Spec, options{})
+	if result.Allowed {
+		t.Fatalf("expected Allowed=false, got true")
+	}
+	if result.ForbiddenDetail == "" || !strings.Contains(result.ForbiddenDetail, "bad") || !strings.Contains(result.ForbiddenDetail, "hostPath") {
+		t.Errorf("expected ForbiddenDetail to name the bad volume and its type, got %q", result.ForbiddenDetail)
+	}
+}
+
+func TestRegisterVolumeTypeCheckerOverridesByName(t *testing.T) {
+	defer func(saved []VolumeTypeChecker) { volumeTypeCheckers = saved }(append([]VolumeTypeChecker{}, volumeTypeCheckers...))
+
+	RegisterVolumeTypeChecker(volumeFieldChecker{
+		name:    "nfs",
+		matches: func(v *corev1.Volume) bool { return v.NFS != nil },
+		allowed: true,
+	})
+
+	pod := &corev1.Pod{Spec: corev1.PodSpec{Volumes: []corev1.Volume{
+		{Name: "a", VolumeSource: corev1.VolumeSource{NFS: &corev1.NFSVolumeSource{}}},
+	}}}
+
+	result := restrictedVolumes_1_0(&pod.ObjectMeta, &pod.Spec, options{})
+	if !result.Allowed {
+		t.Errorf("expected the re-registered nfs checker to allow the volume, got Allowed=false (%s)", result.ForbiddenDetail)
+	}
+}
+
+type constFieldErrorChecker struct {
+	name string
+	err  *field.Error
+}
+
+func (c constFieldErrorChecker) Name() string                       { return c.name }
+func (c constFieldErrorChecker) Matches(volume *corev1.Volume) bool { return volume.CSI != nil }
+func (c constFieldErrorChecker) Allowed(volume *corev1.Volume, opts options) (bool, *field.Error) {
+	return false, c.err
+}
+
+func TestVolumeTypeCheckerFieldErrorIsRootedUnderVolumeIndex(t *testing.T) {
+	defer func(saved []VolumeTypeChecker) { volumeTypeCheckers = saved }(append([]VolumeTypeChecker{}, volumeTypeCheckers...))
+
+	RegisterVolumeTypeChecker(constFieldErrorChecker{
+		name: "csi",
+		err:  field.Forbidden(field.NewPath("driver"), "only ebs.csi.aws.com is permitted"),
+	})
+
+	pod := &corev1.Pod{Spec: corev1.PodSpec{Volumes: []corev1.Volume{
+		{Name: "a", VolumeSource: corev1.VolumeSource{CSI: &corev1.CSIVolumeSource{Driver: "other.csi.example.com"}}},
+	}}}
+
+	result := restrictedVolumes_1_0(&pod.ObjectMeta, &pod.Spec, options{withErrList: true})
+	if result.Allowed {
+		t.Fatalf("expected Allowed=false, got true")
+	}
+	if len(result.ErrList) != 1 {
+		t.Fatalf("expected exactly one field.Error, got %d", len(result.ErrList))
+	}
+	if want := "spec.volumes[0].driver"; result.ErrList[0].Field != want {
+		t.Errorf("expected the checker's field.Error rooted at %q, got %q", want, result.ErrList[0].Field)
+	}
+}